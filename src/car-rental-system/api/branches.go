@@ -0,0 +1,71 @@
+package api
+
+import (
+	models "car-rental-system/rental_system_models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type relocateCarRequest struct {
+	BranchID int `json:"branchId"`
+}
+
+func (s *Server) listBranches(c *gin.Context) {
+	branches, err := s.rentals.ListBranches()
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, branches)
+}
+
+func (s *Server) addBranch(c *gin.Context) {
+	var branch models.Branch
+	if err := c.ShouldBindJSON(&branch); err != nil {
+		abortWithError(c, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	added, err := s.rentals.AddBranch(branch)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusCreated, added)
+}
+
+func (s *Server) getBranch(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	branch, err := s.rentals.GetBranch(id)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, branch)
+}
+
+// relocateCar records a car moved between branches by staff.
+func (s *Server) relocateCar(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var req relocateCarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	car, err := s.rentals.RelocateCar(id, req.BranchID)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, car)
+}
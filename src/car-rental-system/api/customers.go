@@ -0,0 +1,134 @@
+package api
+
+import (
+	models "car-rental-system/rental_system_models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (s *Server) registerCustomer(c *gin.Context) {
+	var customer models.Customer
+	if err := c.ShouldBindJSON(&customer); err != nil {
+		abortWithError(c, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	registered, err := s.rentals.RegisterCustomer(customer)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusCreated, registered)
+}
+
+func (s *Server) getCustomer(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	customer, err := s.rentals.GetCustomer(id)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, customer)
+}
+
+// updateCustomer replaces a customer's details; the id in the path wins
+// over any id in the body.
+func (s *Server) updateCustomer(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var customer models.Customer
+	if err := c.ShouldBindJSON(&customer); err != nil {
+		abortWithError(c, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+	customer.ID = id
+
+	updated, err := s.rentals.UpdateCustomer(customer)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, updated)
+}
+
+func (s *Server) customerHistory(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	history, err := s.rentals.CustomerHistory(id)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	if history == nil {
+		history = []models.Reservation{}
+	}
+	c.IndentedJSON(http.StatusOK, history)
+}
+
+type blockLicenseRequest struct {
+	Reason string `json:"reason"`
+}
+
+func (s *Server) listBlockedLicenses(c *gin.Context) {
+	entries, err := s.rentals.BlockedLicenses()
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, entries)
+}
+
+// blockLicense bans the license in the path; the body may give a reason.
+func (s *Server) blockLicense(c *gin.Context) {
+	var req blockLicenseRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			abortWithError(c, http.StatusBadRequest, "invalid_request", err.Error())
+			return
+		}
+	}
+
+	entry, err := s.rentals.BlockLicense(c.Param("license"), req.Reason)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, entry)
+}
+
+func (s *Server) unblockLicense(c *gin.Context) {
+	if err := s.rentals.UnblockLicense(c.Param("license")); err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// loyaltyAccount returns the customer's points, tier and ledger.
+func (s *Server) loyaltyAccount(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	account, err := s.rentals.GetLoyaltyAccount(id)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	if account.Entries == nil {
+		account.Entries = []models.LoyaltyEntry{}
+	}
+	c.IndentedJSON(http.StatusOK, account)
+}
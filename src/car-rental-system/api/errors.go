@@ -0,0 +1,120 @@
+package api
+
+import (
+	"car-rental-system/eligibility"
+	services "car-rental-system/handlers"
+	"car-rental-system/money"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errorBody is returned for every failed request.
+type errorBody struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type domainError struct {
+	err    error
+	status int
+	code   string
+}
+
+// domainErrors maps the rental system's errors to HTTP responses. Anything
+// not listed here is reported as an internal error.
+var domainErrors = []domainError{
+	{services.ErrCarNotFound, http.StatusNotFound, "car_not_found"},
+	{services.ErrCarRetired, http.StatusConflict, "car_retired"},
+	{services.ErrInvalidCarStatus, http.StatusBadRequest, "invalid_car_status"},
+	{services.ErrMaintenanceNotFound, http.StatusNotFound, "maintenance_not_found"},
+	{services.ErrVehicleClassNotFound, http.StatusNotFound, "vehicle_class_not_found"},
+	{services.ErrVehicleClassExists, http.StatusConflict, "vehicle_class_exists"},
+	{services.ErrInvalidVehicleClass, http.StatusBadRequest, "invalid_vehicle_class"},
+	{services.ErrClassSoldOut, http.StatusConflict, "class_sold_out"},
+	{services.ErrCarAlreadyAssigned, http.StatusConflict, "car_already_assigned"},
+	{services.ErrCarOrClassRequired, http.StatusBadRequest, "car_or_class_required"},
+	{services.ErrInvalidSearch, http.StatusBadRequest, "invalid_search"},
+	{services.ErrInvalidCursor, http.StatusBadRequest, "invalid_cursor"},
+	{services.ErrBranchNotFound, http.StatusNotFound, "branch_not_found"},
+	{services.ErrInvalidBranch, http.StatusBadRequest, "invalid_branch"},
+	{services.ErrBranchClosed, http.StatusUnprocessableEntity, "branch_closed"},
+	{services.ErrCarNotAtBranch, http.StatusConflict, "car_not_at_branch"},
+	{services.ErrCustomerNotFound, http.StatusNotFound, "customer_not_found"},
+	{services.ErrDuplicateLicense, http.StatusConflict, "duplicate_license"},
+	{services.ErrInvalidCustomer, http.StatusBadRequest, "invalid_customer"},
+	{services.ErrLicenseNotBlocked, http.StatusNotFound, "license_not_blocked"},
+	{eligibility.ErrInvalidLicense, http.StatusUnprocessableEntity, "invalid_license"},
+	{eligibility.ErrLicenseExpired, http.StatusUnprocessableEntity, "license_expired"},
+	{eligibility.ErrDriverAge, http.StatusUnprocessableEntity, "driver_age"},
+	{eligibility.ErrMissingDriverDetails, http.StatusUnprocessableEntity, "missing_driver_details"},
+	{eligibility.ErrLicenseBlocked, http.StatusForbidden, "license_blocked"},
+	{services.ErrReservationNotFound, http.StatusNotFound, "reservation_not_found"},
+	{services.ErrReservationCancelled, http.StatusConflict, "reservation_cancelled"},
+	{services.ErrAlreadyPickedUp, http.StatusConflict, "already_picked_up"},
+	{services.ErrNotPickedUp, http.StatusConflict, "not_picked_up"},
+	{services.ErrReservationCompleted, http.StatusConflict, "reservation_completed"},
+	{services.ErrPickupTooEarly, http.StatusConflict, "pickup_too_early"},
+	{services.ErrInvalidHandover, http.StatusBadRequest, "invalid_handover"},
+	{services.ErrCarNotAvailable, http.StatusConflict, "car_not_available"},
+	{services.ErrCarAlreadyExists, http.StatusConflict, "car_already_exists"},
+	{services.ErrAlreadyPaid, http.StatusConflict, "already_paid"},
+	{services.ErrPaymentNotFound, http.StatusNotFound, "payment_not_found"},
+	{services.ErrInvoiceNotFound, http.StatusNotFound, "invoice_not_found"},
+	{services.ErrWaitlistNotFound, http.StatusNotFound, "waitlist_entry_not_found"},
+	{services.ErrAlreadyWaitlisted, http.StatusConflict, "already_waitlisted"},
+	{services.ErrWaitlistEntryClosed, http.StatusConflict, "waitlist_entry_closed"},
+	{services.ErrNoWaitlistOffer, http.StatusConflict, "no_waitlist_offer"},
+	{services.ErrWaitlistOfferExpired, http.StatusGone, "waitlist_offer_expired"},
+	{services.ErrHoldNotFound, http.StatusNotFound, "hold_not_found"},
+	{services.ErrHoldNeedsCar, http.StatusBadRequest, "hold_needs_car"},
+	{services.ErrHoldNotActive, http.StatusConflict, "hold_not_active"},
+	{services.ErrHoldExpired, http.StatusGone, "hold_expired"},
+	{services.ErrExtraNotFound, http.StatusNotFound, "extra_not_found"},
+	{services.ErrExtraExists, http.StatusConflict, "extra_exists"},
+	{services.ErrInvalidExtra, http.StatusBadRequest, "invalid_extra"},
+	{services.ErrExtraNotStocked, http.StatusConflict, "extra_not_stocked"},
+	{services.ErrExtraQuantity, http.StatusBadRequest, "invalid_extra_quantity"},
+	{services.ErrExtraSoldOut, http.StatusConflict, "extra_sold_out"},
+	{services.ErrInvalidPoints, http.StatusBadRequest, "invalid_points"},
+	{services.ErrInsufficientPoints, http.StatusUnprocessableEntity, "insufficient_points"},
+	{services.ErrPromoNotFound, http.StatusNotFound, "promo_not_found"},
+	{services.ErrPromoExists, http.StatusConflict, "promo_exists"},
+	{services.ErrInvalidPromo, http.StatusBadRequest, "invalid_promo"},
+	{services.ErrPromoNotValid, http.StatusUnprocessableEntity, "promo_not_valid"},
+	{services.ErrPromoNotApplicable, http.StatusUnprocessableEntity, "promo_not_applicable"},
+	{services.ErrPromoUsedUp, http.StatusConflict, "promo_used_up"},
+	{services.ErrPromoNotStackable, http.StatusUnprocessableEntity, "promo_not_stackable"},
+	{services.ErrPaymentDeclined, http.StatusPaymentRequired, "payment_declined"},
+	{services.ErrInvalidAmount, http.StatusBadRequest, "invalid_amount"},
+	{services.ErrInvalidPaymentState, http.StatusConflict, "invalid_payment_state"},
+	{services.ErrCurrencyMismatch, http.StatusUnprocessableEntity, "currency_mismatch"},
+	{services.ErrInvalidExchangeRate, http.StatusBadRequest, "invalid_exchange_rate"},
+	{money.ErrInvalidCurrency, http.StatusBadRequest, "invalid_currency"},
+	{money.ErrNoRate, http.StatusUnprocessableEntity, "no_exchange_rate"},
+	{money.ErrCurrencyMismatch, http.StatusUnprocessableEntity, "currency_mismatch"},
+	{services.ErrInvalidDate, http.StatusBadRequest, "invalid_date"},
+	{services.ErrEndBeforeStart, http.StatusBadRequest, "invalid_date_range"},
+	{services.ErrDateInPast, http.StatusBadRequest, "date_in_past"},
+}
+
+func abortWithError(c *gin.Context, status int, code, message string) {
+	c.AbortWithStatusJSON(status, errorBody{Error: errorDetail{Code: code, Message: message}})
+}
+
+// abortWithDomainError writes the response for an error returned by the
+// rental system.
+func abortWithDomainError(c *gin.Context, err error) {
+	for _, de := range domainErrors {
+		if errors.Is(err, de.err) {
+			abortWithError(c, de.status, de.code, err.Error())
+			return
+		}
+	}
+	abortWithError(c, http.StatusInternalServerError, "internal_error", err.Error())
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type conversionResponse struct {
+	From money.Money `json:"from"`
+	To   money.Money `json:"to"`
+}
+
+func (s *Server) listExchangeRates(c *gin.Context) {
+	rates, err := s.rentals.ListExchangeRates()
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	if rates == nil {
+		rates = []models.ExchangeRate{}
+	}
+	c.IndentedJSON(http.StatusOK, rates)
+}
+
+func (s *Server) addExchangeRate(c *gin.Context) {
+	var rate money.Rate
+	if err := c.ShouldBindJSON(&rate); err != nil {
+		abortWithError(c, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	added, err := s.rentals.AddExchangeRate(rate)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusCreated, added)
+}
+
+// convertAmount handles /exchange-rates/convert?amount=19.99&from=EUR&to=USD
+// at today's rates.
+func (s *Server) convertAmount(c *gin.Context) {
+	amount, ok := moneyValue(c, "invalid_amount", "amount", c.Query("amount"), c.Query("from"))
+	if !ok {
+		return
+	}
+
+	converted, err := s.rentals.ConvertAmount(amount, money.Currency(c.Query("to")))
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, conversionResponse{From: amount, To: converted})
+}
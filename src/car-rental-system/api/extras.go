@@ -0,0 +1,121 @@
+package api
+
+import (
+	services "car-rental-system/handlers"
+	models "car-rental-system/rental_system_models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type extraRequest struct {
+	Code     string `json:"code" binding:"required"`
+	Quantity int    `json:"quantity" binding:"required"`
+}
+
+func toServiceExtras(extras []extraRequest) []services.ExtraRequest {
+	var result []services.ExtraRequest
+	for _, extra := range extras {
+		result = append(result, services.ExtraRequest{Code: extra.Code, Quantity: extra.Quantity})
+	}
+	return result
+}
+
+// setExtrasRequest replaces a reservation's extras; an empty list removes
+// them.
+type setExtrasRequest struct {
+	Extras []extraRequest `json:"extras" binding:"dive"`
+}
+
+type extraStockRequest struct {
+	BranchID int  `json:"branchId"`
+	Quantity *int `json:"quantity" binding:"required"`
+}
+
+func (s *Server) listExtras(c *gin.Context) {
+	extras, err := s.rentals.ListExtras()
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, extras)
+}
+
+func (s *Server) addExtra(c *gin.Context) {
+	var extra models.Extra
+	if err := c.ShouldBindJSON(&extra); err != nil {
+		abortWithError(c, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	added, err := s.rentals.AddExtra(extra)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusCreated, added)
+}
+
+func (s *Server) getExtra(c *gin.Context) {
+	extra, err := s.rentals.GetExtra(c.Param("code"))
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, extra)
+}
+
+// setExtraStock sets the units a branch holds; branchId zero is the stock
+// kept with cars that are not tied to a branch.
+func (s *Server) setExtraStock(c *gin.Context) {
+	var req extraStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	extra, err := s.rentals.SetExtraStock(c.Param("code"), req.BranchID, *req.Quantity)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, extra)
+}
+
+// extraAvailability handles /extras/availability?start=...&end=...&branch=1
+func (s *Server) extraAvailability(c *gin.Context) {
+	branchID, ok := branchQuery(c)
+	if !ok {
+		return
+	}
+
+	availability, err := s.rentals.AvailableExtras(c.Query("start"), c.Query("end"), branchID)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	if availability == nil {
+		availability = []services.ExtraAvailability{}
+	}
+	c.IndentedJSON(http.StatusOK, availability)
+}
+
+func (s *Server) setReservationExtras(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var req setExtrasRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	res, err := s.rentals.SetReservationExtras(id, toServiceExtras(req.Extras))
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, res)
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	models "car-rental-system/rental_system_models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type carStatusRequest struct {
+	Status models.CarStatus `json:"status" binding:"required"`
+}
+
+type carStatusResponse struct {
+	CarID                int                  `json:"carId"`
+	Status               models.CarStatus     `json:"status"`
+	AffectedReservations []models.Reservation `json:"affectedReservations"`
+}
+
+type maintenanceRequest struct {
+	StartDate string `json:"startDate" binding:"required"`
+	EndDate   string `json:"endDate" binding:"required"`
+	Reason    string `json:"reason"`
+}
+
+func (s *Server) setCarStatus(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var req carStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	affected, err := s.rentals.SetCarStatus(id, req.Status)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	if affected == nil {
+		affected = []models.Reservation{}
+	}
+	c.IndentedJSON(http.StatusOK, carStatusResponse{CarID: id, Status: req.Status, AffectedReservations: affected})
+}
+
+func (s *Server) listMaintenance(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	windows, err := s.rentals.MaintenanceSchedule(id)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	if windows == nil {
+		windows = []models.MaintenanceWindow{}
+	}
+	c.IndentedJSON(http.StatusOK, windows)
+}
+
+func (s *Server) scheduleMaintenance(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var req maintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	result, err := s.rentals.ScheduleMaintenance(id, req.StartDate, req.EndDate, req.Reason)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	if result.AffectedReservations == nil {
+		result.AffectedReservations = []models.Reservation{}
+	}
+	c.IndentedJSON(http.StatusCreated, result)
+}
+
+func (s *Server) cancelMaintenance(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	if err := s.rentals.CancelMaintenance(id); err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	services "car-rental-system/handlers"
+	models "car-rental-system/rental_system_models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handoverRequest is what counter staff record at pickup and return. The
+// readings are pointers so that zero can be told apart from missing.
+type handoverRequest struct {
+	Odometer    *int   `json:"odometer" binding:"required"`
+	EnergyLevel *int   `json:"energyLevel" binding:"required"`
+	DamageNotes string `json:"damageNotes"`
+	BranchID    int    `json:"branchId"`
+}
+
+// bindHandover reads the path id and the handover body.
+func bindHandover(c *gin.Context) (int, services.HandoverReport, bool) {
+	id, ok := idParam(c)
+	if !ok {
+		return 0, services.HandoverReport{}, false
+	}
+	var req handoverRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, http.StatusBadRequest, "invalid_request", err.Error())
+		return 0, services.HandoverReport{}, false
+	}
+	return id, services.HandoverReport{
+		Odometer:    *req.Odometer,
+		EnergyLevel: *req.EnergyLevel,
+		DamageNotes: req.DamageNotes,
+		BranchID:    req.BranchID,
+	}, true
+}
+
+func (s *Server) pickUpCar(c *gin.Context) {
+	id, report, ok := bindHandover(c)
+	if !ok {
+		return
+	}
+
+	res, err := s.rentals.CheckOut(id, report)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, res)
+}
+
+// returnCar completes the rental and reports the usage charges added.
+func (s *Server) returnCar(c *gin.Context) {
+	id, report, ok := bindHandover(c)
+	if !ok {
+		return
+	}
+
+	result, err := s.rentals.CheckIn(id, report)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	if result.Charges == nil {
+		result.Charges = []models.PriceLine{}
+	}
+	c.IndentedJSON(http.StatusOK, result)
+}
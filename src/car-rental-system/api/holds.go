@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// placeHold takes the same body as a reservation, with carId required.
+func (s *Server) placeHold(c *gin.Context) {
+	var req createReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	hold, err := s.rentals.PlaceHold(req.toService())
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusCreated, hold)
+}
+
+func (s *Server) getHold(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	hold, err := s.rentals.GetHold(id)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, hold)
+}
+
+// confirmHold turns the hold into a reservation and returns it.
+func (s *Server) confirmHold(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	res, err := s.rentals.ConfirmHold(id)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusCreated, res)
+}
+
+func (s *Server) releaseHold(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	if err := s.rentals.ReleaseHold(id); err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"bytes"
+	"car-rental-system/invoicing"
+	models "car-rental-system/rental_system_models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// reservationInvoices lists the invoices and credit notes of a reservation.
+func (s *Server) reservationInvoices(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	invoices, err := s.rentals.Invoices(id)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	if invoices == nil {
+		invoices = []models.Invoice{}
+	}
+	c.IndentedJSON(http.StatusOK, invoices)
+}
+
+// getInvoice handles /invoices/:id?format=json|html|text
+func (s *Server) getInvoice(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	invoice, err := s.rentals.GetInvoice(id)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	var contentType string
+	switch format := c.DefaultQuery("format", "json"); format {
+	case "json":
+		c.IndentedJSON(http.StatusOK, invoice)
+		return
+	case "html":
+		err = invoicing.RenderHTML(&buf, invoice)
+		contentType = "text/html; charset=utf-8"
+	case "text":
+		err = invoicing.RenderText(&buf, invoice)
+		contentType = "text/plain; charset=utf-8"
+	default:
+		abortWithError(c, http.StatusBadRequest, "invalid_format", "format must be json, html or text")
+		return
+	}
+	if err != nil {
+		abortWithError(c, http.StatusInternalServerError, "internal_error", err.Error())
+		return
+	}
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+// exportInvoices handles /invoices?from=2026-01-01&to=2026-02-01&format=csv
+// for accounting.
+func (s *Server) exportInvoices(c *gin.Context) {
+	invoices, err := s.rentals.IssuedInvoices(c.Query("from"), c.Query("to"))
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	contentType := "application/json; charset=utf-8"
+	switch format := c.DefaultQuery("format", "json"); format {
+	case "json":
+		err = invoicing.WriteJSON(&buf, invoices)
+	case "csv":
+		err = invoicing.WriteCSV(&buf, invoices)
+		contentType = "text/csv; charset=utf-8"
+		c.Header("Content-Disposition", `attachment; filename="invoices.csv"`)
+	default:
+		abortWithError(c, http.StatusBadRequest, "invalid_format", "format must be json or csv")
+		return
+	}
+	if err != nil {
+		abortWithError(c, http.StatusInternalServerError, "internal_error", err.Error())
+		return
+	}
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	models "car-rental-system/rental_system_models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (s *Server) listPromoCodes(c *gin.Context) {
+	promos, err := s.rentals.ListPromoCodes()
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	if promos == nil {
+		promos = []models.PromoCode{}
+	}
+	c.IndentedJSON(http.StatusOK, promos)
+}
+
+func (s *Server) addPromoCode(c *gin.Context) {
+	var promo models.PromoCode
+	if err := c.ShouldBindJSON(&promo); err != nil {
+		abortWithError(c, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	added, err := s.rentals.AddPromoCode(promo)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusCreated, added)
+}
+
+func (s *Server) getPromoCode(c *gin.Context) {
+	promo, err := s.rentals.GetPromoCode(c.Param("code"))
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, promo)
+}
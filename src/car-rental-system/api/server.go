@@ -0,0 +1,548 @@
+package api
+
+import (
+	services "car-rental-system/handlers"
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Server exposes a RentalSystem over HTTP.
+type Server struct {
+	rentals *services.RentalSystem
+}
+
+func NewServer(rentals *services.RentalSystem) *Server {
+	return &Server{rentals: rentals}
+}
+
+// Router returns the gin engine with every route registered.
+func (s *Server) Router() *gin.Engine {
+	router := gin.Default()
+
+	router.GET("/cars", s.listCars)
+	router.GET("/cars/search", s.searchCars)
+	router.GET("/cars/:id/availability", s.carAvailability)
+	router.PUT("/cars/:id/status", s.setCarStatus)
+	router.GET("/cars/:id/maintenance", s.listMaintenance)
+	router.POST("/cars/:id/maintenance", s.scheduleMaintenance)
+	router.PUT("/cars/:id/location", s.relocateCar)
+	router.DELETE("/maintenance/:id", s.cancelMaintenance)
+
+	router.GET("/classes", s.listVehicleClasses)
+	router.POST("/classes", s.addVehicleClass)
+	router.GET("/classes/availability", s.classAvailability)
+
+	router.GET("/extras", s.listExtras)
+	router.POST("/extras", s.addExtra)
+	router.GET("/extras/availability", s.extraAvailability)
+	router.GET("/extras/:code", s.getExtra)
+	router.PUT("/extras/:code/stock", s.setExtraStock)
+	router.GET("/promos", s.listPromoCodes)
+	router.POST("/promos", s.addPromoCode)
+	router.GET("/promos/:code", s.getPromoCode)
+
+	router.GET("/branches", s.listBranches)
+	router.POST("/branches", s.addBranch)
+	router.GET("/branches/:id", s.getBranch)
+
+	router.POST("/customers", s.registerCustomer)
+	router.GET("/customers/:id", s.getCustomer)
+	router.PUT("/customers/:id", s.updateCustomer)
+	router.GET("/customers/:id/reservations", s.customerHistory)
+	router.GET("/customers/:id/waitlist", s.customerWaitlist)
+	router.GET("/customers/:id/loyalty", s.loyaltyAccount)
+
+	router.GET("/blocklist", s.listBlockedLicenses)
+	router.PUT("/blocklist/:license", s.blockLicense)
+	router.DELETE("/blocklist/:license", s.unblockLicense)
+
+	router.POST("/quotes", s.quoteReservation)
+	router.POST("/reservations", s.createReservation)
+	router.GET("/reservations/:id", s.getReservation)
+	router.PATCH("/reservations/:id", s.modifyReservation)
+	router.DELETE("/reservations/:id", s.cancelReservation)
+	router.POST("/reservations/:id/car", s.assignCar)
+	router.PUT("/reservations/:id/extras", s.setReservationExtras)
+	router.POST("/reservations/:id/pickup", s.pickUpCar)
+	router.POST("/reservations/:id/return", s.returnCar)
+	router.POST("/reservations/:id/payment", s.payReservation)
+	router.POST("/reservations/:id/authorizations", s.authorizePayment)
+	router.GET("/reservations/:id/payments", s.listPayments)
+	router.GET("/reservations/:id/invoices", s.reservationInvoices)
+
+	router.POST("/holds", s.placeHold)
+	router.GET("/holds/:id", s.getHold)
+	router.POST("/holds/:id/confirm", s.confirmHold)
+	router.DELETE("/holds/:id", s.releaseHold)
+
+	router.GET("/waitlist", s.listWaitlist)
+	router.POST("/waitlist", s.joinWaitlist)
+	router.GET("/waitlist/:id", s.getWaitlistEntry)
+	router.DELETE("/waitlist/:id", s.leaveWaitlist)
+	router.POST("/waitlist/:id/accept", s.acceptWaitlistOffer)
+
+	router.GET("/invoices", s.exportInvoices)
+	router.GET("/invoices/:id", s.getInvoice)
+
+	router.GET("/exchange-rates", s.listExchangeRates)
+	router.POST("/exchange-rates", s.addExchangeRate)
+	router.GET("/exchange-rates/convert", s.convertAmount)
+
+	router.POST("/payments/:id/capture", s.capturePayment)
+	router.POST("/payments/:id/void", s.voidPayment)
+	router.POST("/payments/:id/refund", s.refundPayment)
+
+	return router
+}
+
+// createReservationRequest books either a car or, when carId is left out,
+// a vehicle class.
+type createReservationRequest struct {
+	CustomerID      int            `json:"customerId" binding:"required"`
+	CarID           int            `json:"carId"`
+	VehicleClass    string         `json:"vehicleClass"`
+	StartDate       string         `json:"startDate" binding:"required"`
+	EndDate         string         `json:"endDate" binding:"required"`
+	PickupBranchID  int            `json:"pickupBranchId"`
+	DropoffBranchID int            `json:"dropoffBranchId"`
+	Extras          []extraRequest `json:"extras" binding:"dive"`
+	PromoCode       string         `json:"promoCode"`
+	RedeemPoints    int            `json:"redeemPoints"`
+}
+
+func (r createReservationRequest) toService() services.ReservationRequest {
+	return services.ReservationRequest{
+		CustomerID:      r.CustomerID,
+		CarID:           r.CarID,
+		VehicleClass:    r.VehicleClass,
+		StartDate:       r.StartDate,
+		EndDate:         r.EndDate,
+		PickupBranchID:  r.PickupBranchID,
+		DropoffBranchID: r.DropoffBranchID,
+		Extras:          toServiceExtras(r.Extras),
+		PromoCode:       r.PromoCode,
+		RedeemPoints:    r.RedeemPoints,
+	}
+}
+
+type modifyReservationRequest struct {
+	StartDate string `json:"startDate" binding:"required"`
+	EndDate   string `json:"endDate" binding:"required"`
+}
+
+// paymentRequest is the body of payment calls. Amount may be left out to
+// pay the outstanding balance or refund everything; otherwise it needs the
+// currency of the reservation.
+type paymentRequest struct {
+	Amount   json.Number `json:"amount"`
+	Currency string      `json:"currency"`
+	Method   string      `json:"method"`
+	// money is Amount in Currency, set by bindPayment.
+	money money.Money
+}
+
+const defaultPaymentMethod = "card"
+
+// bindPayment reads an optional payment body.
+func bindPayment(c *gin.Context) (paymentRequest, bool) {
+	var req paymentRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			abortWithError(c, http.StatusBadRequest, "invalid_request", err.Error())
+			return req, false
+		}
+	}
+	if req.Method == "" {
+		req.Method = defaultPaymentMethod
+	}
+	amount, ok := moneyValue(c, "invalid_amount", "amount", string(req.Amount), req.Currency)
+	if !ok {
+		return req, false
+	}
+	req.money = amount
+	return req, true
+}
+
+// moneyValue reads a decimal amount in currency, answering 400 with code
+// when either is malformed. Amounts other than zero need a currency.
+func moneyValue(c *gin.Context, code, name, amount, currency string) (money.Money, bool) {
+	var m money.Money
+	if currency != "" {
+		parsed, err := money.ParseCurrency(currency)
+		if err != nil {
+			abortWithError(c, http.StatusBadRequest, code, err.Error())
+			return m, false
+		}
+		m.Currency = parsed
+	}
+	if amount == "" {
+		return m, true
+	}
+	parsed, err := money.Parse(amount, m.Currency)
+	if err != nil {
+		abortWithError(c, http.StatusBadRequest, code, name+" must be a decimal amount with no more places than the currency has")
+		return m, false
+	}
+	if m.Currency == "" && !parsed.IsZero() {
+		abortWithError(c, http.StatusBadRequest, code, name+" needs a currency")
+		return m, false
+	}
+	return parsed, true
+}
+
+type quoteResponse struct {
+	Lines []models.PriceLine `json:"lines"`
+	Total money.Money        `json:"total"`
+}
+
+type availabilityResponse struct {
+	CarID     int    `json:"carId"`
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+	Available bool   `json:"available"`
+}
+
+// branchQuery reads the optional branch query parameter, answering 400
+// when it is not a number.
+func branchQuery(c *gin.Context) (int, bool) {
+	raw, ok := c.GetQuery("branch")
+	if !ok {
+		return 0, true
+	}
+	id, err := strconv.Atoi(raw)
+	if err != nil {
+		abortWithError(c, http.StatusBadRequest, "invalid_branch", "branch must be a number")
+		return 0, false
+	}
+	return id, true
+}
+
+// idParam reads a numeric path parameter, answering 400 when it is not one.
+func idParam(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		abortWithError(c, http.StatusBadRequest, "invalid_id", "id must be a number")
+		return 0, false
+	}
+	return id, true
+}
+
+func (s *Server) listCars(c *gin.Context) {
+	cars, err := s.rentals.ListCars()
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, cars)
+}
+
+// intQuery reads an optional numeric query parameter, answering 400 when it
+// is not a number.
+func intQuery(c *gin.Context, name string) (int, bool) {
+	raw, ok := c.GetQuery(name)
+	if !ok {
+		return 0, true
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		abortWithError(c, http.StatusBadRequest, "invalid_search", name+" must be a number")
+		return 0, false
+	}
+	return value, true
+}
+
+// priceQuery is intQuery for prices, in the currency query parameter.
+// Without one the price is in the rental system's base currency.
+func (s *Server) priceQuery(c *gin.Context, name string) (money.Money, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return money.Money{}, true
+	}
+	currency := c.Query("currency")
+	if currency == "" {
+		currency = string(s.rentals.BaseCurrency())
+	}
+	return moneyValue(c, "invalid_search", name, raw, currency)
+}
+
+// searchCriteria reads the search query parameters.
+func (s *Server) searchCriteria(c *gin.Context) (services.SearchCriteria, bool) {
+	criteria := services.SearchCriteria{
+		Make:      c.Query("make"),
+		Model:     c.Query("model"),
+		Class:     c.Query("class"),
+		StartDate: c.Query("start"),
+		EndDate:   c.Query("end"),
+		Sort:      services.SearchSort(c.Query("sort")),
+		Cursor:    c.Query("cursor"),
+	}
+	switch c.DefaultQuery("order", "asc") {
+	case "asc":
+	case "desc":
+		criteria.Descending = true
+	default:
+		abortWithError(c, http.StatusBadRequest, "invalid_search", "order must be asc or desc")
+		return criteria, false
+	}
+
+	ints := []struct {
+		name  string
+		value *int
+	}{
+		{"minYear", &criteria.MinYear},
+		{"maxYear", &criteria.MaxYear},
+		{"seats", &criteria.MinSeats},
+		{"limit", &criteria.Limit},
+		{"offset", &criteria.Offset},
+	}
+	for _, param := range ints {
+		value, ok := intQuery(c, param.name)
+		if !ok {
+			return criteria, false
+		}
+		*param.value = value
+	}
+	var ok bool
+	if criteria.MinPrice, ok = s.priceQuery(c, "minPrice"); !ok {
+		return criteria, false
+	}
+	if criteria.MaxPrice, ok = s.priceQuery(c, "maxPrice"); !ok {
+		return criteria, false
+	}
+	if criteria.BranchID, ok = branchQuery(c); !ok {
+		return criteria, false
+	}
+	return criteria, true
+}
+
+// searchCars handles
+// /cars/search?make=Toyota&class=compact&maxPrice=100&start=...&end=...&branch=1&sort=price&order=desc&limit=20&cursor=...
+func (s *Server) searchCars(c *gin.Context) {
+	criteria, ok := s.searchCriteria(c)
+	if !ok {
+		return
+	}
+
+	result, err := s.rentals.SearchCars(criteria)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	if result.Cars == nil {
+		result.Cars = []models.Car{}
+	}
+	c.IndentedJSON(http.StatusOK, result)
+}
+
+func (s *Server) carAvailability(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	start, end := c.Query("start"), c.Query("end")
+	available, err := s.rentals.IsCarAvailable(id, start, end)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, availabilityResponse{CarID: id, StartDate: start, EndDate: end, Available: available})
+}
+
+// quoteReservation prices a booking request without making it.
+func (s *Server) quoteReservation(c *gin.Context) {
+	var req createReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	quote, err := s.rentals.QuoteReservation(req.toService())
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, quoteResponse{Lines: quote.Lines, Total: quote.Total})
+}
+
+func (s *Server) createReservation(c *gin.Context) {
+	var req createReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	res, err := s.rentals.CreateReservation(req.toService())
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusCreated, res)
+}
+
+// assignCar picks the car for a class-only reservation at pickup.
+func (s *Server) assignCar(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	res, err := s.rentals.AssignCar(id)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, res)
+}
+
+func (s *Server) getReservation(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	res, err := s.rentals.GetReservation(id)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, res)
+}
+
+func (s *Server) modifyReservation(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var req modifyReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	if err := s.rentals.ModifyReservation(id, req.StartDate, req.EndDate); err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	s.getReservation(c)
+}
+
+func (s *Server) cancelReservation(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	result, err := s.rentals.CancelReservation(id)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, result)
+}
+
+func (s *Server) payReservation(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	req, ok := bindPayment(c)
+	if !ok {
+		return
+	}
+
+	payment, err := s.rentals.ProcessPayment(id, req.money, req.Method)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusCreated, payment)
+}
+
+func (s *Server) authorizePayment(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	req, ok := bindPayment(c)
+	if !ok {
+		return
+	}
+
+	payment, err := s.rentals.AuthorizePayment(id, req.money, req.Method)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusCreated, payment)
+}
+
+func (s *Server) listPayments(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	list, err := s.rentals.Payments(id)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	if list == nil {
+		list = []models.Payment{}
+	}
+	c.IndentedJSON(http.StatusOK, list)
+}
+
+func (s *Server) capturePayment(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	payment, err := s.rentals.CapturePayment(id)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, payment)
+}
+
+func (s *Server) voidPayment(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	payment, err := s.rentals.VoidPayment(id)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, payment)
+}
+
+func (s *Server) refundPayment(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	req, ok := bindPayment(c)
+	if !ok {
+		return
+	}
+
+	payment, err := s.rentals.RefundPayment(id, req.money)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, payment)
+}
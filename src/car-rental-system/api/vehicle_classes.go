@@ -0,0 +1,47 @@
+package api
+
+import (
+	models "car-rental-system/rental_system_models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (s *Server) listVehicleClasses(c *gin.Context) {
+	classes, err := s.rentals.ListVehicleClasses()
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, classes)
+}
+
+func (s *Server) addVehicleClass(c *gin.Context) {
+	var class models.VehicleClass
+	if err := c.ShouldBindJSON(&class); err != nil {
+		abortWithError(c, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	added, err := s.rentals.AddVehicleClass(class)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusCreated, added)
+}
+
+// classAvailability handles /classes/availability?start=...&end=...&branch=1
+func (s *Server) classAvailability(c *gin.Context) {
+	branchID, ok := branchQuery(c)
+	if !ok {
+		return
+	}
+
+	availability, err := s.rentals.AvailableClasses(c.Query("start"), c.Query("end"), branchID)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, availability)
+}
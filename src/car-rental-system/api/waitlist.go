@@ -0,0 +1,97 @@
+package api
+
+import (
+	models "car-rental-system/rental_system_models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// joinWaitlist takes the same body as a reservation.
+func (s *Server) joinWaitlist(c *gin.Context) {
+	var req createReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	entry, err := s.rentals.JoinWaitlist(req.toService())
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusCreated, entry)
+}
+
+func (s *Server) listWaitlist(c *gin.Context) {
+	entries, err := s.rentals.ListWaitlist()
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	if entries == nil {
+		entries = []models.WaitlistEntry{}
+	}
+	c.IndentedJSON(http.StatusOK, entries)
+}
+
+func (s *Server) getWaitlistEntry(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	entry, err := s.rentals.GetWaitlistEntry(id)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, entry)
+}
+
+// leaveWaitlist also turns down an open offer.
+func (s *Server) leaveWaitlist(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	if err := s.rentals.LeaveWaitlist(id); err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// acceptWaitlistOffer books the held car or class and returns the new
+// reservation.
+func (s *Server) acceptWaitlistOffer(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	res, err := s.rentals.AcceptWaitlistOffer(id)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusCreated, res)
+}
+
+func (s *Server) customerWaitlist(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	entries, err := s.rentals.CustomerWaitlist(id)
+	if err != nil {
+		abortWithDomainError(c, err)
+		return
+	}
+	if entries == nil {
+		entries = []models.WaitlistEntry{}
+	}
+	c.IndentedJSON(http.StatusOK, entries)
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"car-rental-system/events"
+	services "car-rental-system/handlers"
+	"car-rental-system/invoicing"
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"fmt"
+	"os"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// daysFromNow formats a date relative to today so the demo bookings are
+// never in the past.
+func daysFromNow(n int) string {
+	return time.Now().AddDate(0, 0, n).Format(dateLayout)
+}
+
+// runDemo walks through the rental flow once and prints each step.
+func runDemo(rentalSystem *services.RentalSystem, bus *events.Bus) {
+	published := make(map[string]int)
+	unsubscribe := bus.Subscribe(events.SubscriberFunc(func(e events.Event) {
+		published[e.Name()]++
+	}))
+	defer unsubscribe()
+
+	// Adding vehicle classes, exchange rates, branches and cars
+	for _, class := range vehicleClasses() {
+		rentalSystem.AddVehicleClass(class)
+	}
+	for _, rate := range exchangeRates() {
+		rentalSystem.AddExchangeRate(rate)
+	}
+	downtown, _ := rentalSystem.AddBranch(models.Branch{Name: "Downtown", OpeningHours: openDaily("08:00", "20:00")})
+	airport, _ := rentalSystem.AddBranch(models.Branch{Name: "Airport", OpeningHours: openDaily("05:00", "23:30")})
+	rentalSystem.AddCar(models.Car{ID: 1, Make: "Toyota", Model: "Corolla", Year: 2020, LicensePlate: "ABC123", RentalPricePerDay: money.New(50_00, money.USD), Class: "economy", BranchID: downtown.ID})
+	rentalSystem.AddCar(models.Car{ID: 2, Make: "Honda", Model: "Civic", Year: 2021, LicensePlate: "XYZ789", RentalPricePerDay: money.New(60_00, money.USD), Class: "compact", BranchID: airport.ID,
+		CancellationPolicy: models.CancellationPolicy{Name: "Saver", NonRefundable: true}})
+
+	// Searching cars
+	if found, err := rentalSystem.SearchCars(services.SearchCriteria{
+		Make: "toyota", MaxPrice: money.New(100_00, money.USD), StartDate: daysFromNow(1), EndDate: daysFromNow(4), BranchID: downtown.ID,
+	}); err == nil {
+		fmt.Println("Available Cars:", found.Cars)
+	}
+
+	// Registering a customer
+	customer, err := rentalSystem.RegisterCustomer(models.Customer{
+		Name: "John Doe", Email: "john.doe@example.com", DateOfBirth: time.Date(1990, time.May, 4, 0, 0, 0, 0, time.UTC),
+		DriversLicense: "D1234567", LicenseRegion: "US-CA", LicenseExpiry: time.Now().AddDate(3, 0, 0),
+	})
+	if err != nil {
+		fmt.Println("Registration failed:", err)
+		return
+	}
+
+	// Drivers whose license runs out during the rental are turned away
+	expiring, err := rentalSystem.RegisterCustomer(models.Customer{
+		Name: "Jane Roe", Email: "jane.roe@example.com", DateOfBirth: time.Date(1985, time.March, 12, 0, 0, 0, 0, time.UTC),
+		DriversLicense: "E7654321", LicenseRegion: "US-CA", LicenseExpiry: time.Now().AddDate(0, 0, 2),
+	})
+	if err == nil {
+		if _, err := rentalSystem.CreateReservation(services.ReservationRequest{
+			CustomerID: expiring.ID, CarID: 1, StartDate: daysFromNow(1), EndDate: daysFromNow(4),
+		}); err != nil {
+			fmt.Println("Reservation rejected:", err)
+		}
+	}
+
+	// Creating reservation
+	reservation, err := rentalSystem.CreateReservation(services.ReservationRequest{
+		CustomerID: customer.ID, CarID: 1, StartDate: daysFromNow(1), EndDate: daysFromNow(4),
+	})
+	if err != nil {
+		fmt.Println("Reservation failed:", err)
+		return
+	}
+	fmt.Println("Reservation created:", *reservation)
+
+	// Processing payment
+	if payment, err := rentalSystem.ProcessPayment(reservation.ID, money.Money{}, "card"); err == nil {
+		fmt.Printf("Payment successful: %s (%s)\n", payment.Amount, payment.ProviderReference)
+	}
+
+	// Modifying reservation
+	if err := rentalSystem.ModifyReservation(reservation.ID, daysFromNow(3), daysFromNow(8)); err == nil {
+		reservation, _ = rentalSystem.GetReservation(reservation.ID)
+		fmt.Printf("Reservation modified successfully, new total %s, amount due %s\n", reservation.TotalPrice, reservation.AmountDue)
+	}
+
+	// Canceling reservation
+	if result, err := rentalSystem.CancelReservation(reservation.ID); err == nil {
+		fmt.Printf("Reservation cancelled, fee %s, refunded %s\n", result.Fee, result.Refund)
+	}
+
+	// Invoices and credit notes for the changes above
+	if invoices, err := rentalSystem.Invoices(reservation.ID); err == nil {
+		for _, invoice := range invoices {
+			fmt.Printf("%s %s: %s (%s)\n", invoicing.Title(&invoice), invoice.Number, invoice.Total, invoice.Status)
+		}
+		if len(invoices) > 0 {
+			invoicing.RenderText(os.Stdout, &invoices[len(invoices)-1])
+		}
+	}
+
+	// Back-to-back bookings on the same car
+	first, err := rentalSystem.CreateReservation(services.ReservationRequest{
+		CustomerID: customer.ID, CarID: 2, StartDate: daysFromNow(1), EndDate: daysFromNow(3),
+	})
+	if err == nil {
+		fmt.Println("Reservation created:", *first)
+	}
+	second, err := rentalSystem.CreateReservation(services.ReservationRequest{
+		CustomerID: customer.ID, CarID: 2, StartDate: daysFromNow(3), EndDate: daysFromNow(5),
+	})
+	if err == nil {
+		fmt.Println("Reservation created:", *second)
+	}
+
+	// Rental history
+	if history, err := rentalSystem.CustomerHistory(customer.ID); err == nil {
+		fmt.Println("Reservations made by", customer.Name+":", len(history))
+	}
+
+	//Checking for the car availability
+	if availability, err := rentalSystem.IsCarAvailableOnDate(2, daysFromNow(4)); err == nil {
+		fmt.Println("Is the car available:", availability)
+	}
+
+	// Waiting for a taken car until a cancellation frees it
+	waiting, err := rentalSystem.RegisterCustomer(models.Customer{
+		Name: "Sam Lee", Email: "sam.lee@example.com", DateOfBirth: time.Date(1992, time.August, 20, 0, 0, 0, 0, time.UTC),
+		DriversLicense: "F2468135", LicenseRegion: "US-CA", LicenseExpiry: time.Now().AddDate(2, 0, 0),
+	})
+	if err == nil && first != nil {
+		request := services.ReservationRequest{CustomerID: waiting.ID, CarID: 2, StartDate: daysFromNow(1), EndDate: daysFromNow(3)}
+		if _, err := rentalSystem.CreateReservation(request); err != nil {
+			entry, err := rentalSystem.JoinWaitlist(request)
+			if err == nil {
+				fmt.Printf("%s joined the waitlist (%s)\n", waiting.Name, entry.Status)
+				rentalSystem.CancelReservation(first.ID)
+				entry, _ = rentalSystem.GetWaitlistEntry(entry.ID)
+				fmt.Printf("Waitlist entry %d: %s until %s\n", entry.ID, entry.Status, entry.OfferExpiresAt.Format(time.Kitchen))
+				if res, err := rentalSystem.AcceptWaitlistOffer(entry.ID); err == nil {
+					fmt.Printf("Offer accepted, reservation %d for %s\n", res.ID, waiting.Name)
+				}
+			}
+		}
+	}
+
+	// Two-phase booking: the car is held while the customer checks out
+	held, err := rentalSystem.PlaceHold(services.ReservationRequest{
+		CustomerID: customer.ID, CarID: 1, StartDate: daysFromNow(20), EndDate: daysFromNow(22),
+	})
+	if err == nil {
+		fmt.Printf("Car %d held until %s\n", held.CarID, held.ExpiresAt.Format(time.Kitchen))
+		if available, err := rentalSystem.IsCarAvailable(1, daysFromNow(20), daysFromNow(22)); err == nil {
+			fmt.Println("Is the held car available:", available)
+		}
+		if res, err := rentalSystem.ConfirmHold(held.ID); err == nil {
+			fmt.Printf("Hold confirmed, reservation %d, total %s\n", res.ID, res.TotalPrice)
+		}
+	}
+
+	// One-way rental from the airport to downtown
+	oneWay, err := rentalSystem.CreateReservation(services.ReservationRequest{
+		CustomerID: customer.ID, CarID: 2, StartDate: daysFromNow(8), EndDate: daysFromNow(10),
+		PickupBranchID: airport.ID, DropoffBranchID: downtown.ID,
+	})
+	if err == nil {
+		fmt.Printf("One-way reservation created, total %s\n", oneWay.TotalPrice)
+	}
+	if found, err := rentalSystem.SearchCars(services.SearchCriteria{
+		MaxPrice: money.New(100_00, money.USD), StartDate: daysFromNow(10), EndDate: daysFromNow(12), BranchID: downtown.ID,
+	}); err == nil {
+		fmt.Println("Cars at downtown after the one-way rental:", found.Total)
+	}
+
+	// Booking a class and getting a free upgrade when it sells out
+	var classBookings []*models.Reservation
+	for range 2 {
+		res, err := rentalSystem.CreateReservation(services.ReservationRequest{
+			CustomerID: customer.ID, VehicleClass: "economy", StartDate: daysFromNow(12), EndDate: daysFromNow(14),
+		})
+		if err != nil {
+			fmt.Println("Class reservation failed:", err)
+			continue
+		}
+		classBookings = append(classBookings, res)
+	}
+	for _, res := range classBookings {
+		if assigned, err := rentalSystem.AssignCar(res.ID); err == nil {
+			fmt.Printf("Reservation %d gets car %d (upgraded: %t), total %s\n", assigned.ID, assigned.CarID, assigned.Upgraded, assigned.TotalPrice)
+		}
+	}
+
+	// Scheduling maintenance over existing bookings
+	if result, err := rentalSystem.ScheduleMaintenance(2, daysFromNow(2), daysFromNow(6), "tyre change"); err == nil {
+		for _, res := range result.AffectedReservations {
+			fmt.Println("Reservation to rebook:", res.ID)
+		}
+	}
+	if found, err := rentalSystem.SearchCars(services.SearchCriteria{StartDate: daysFromNow(6), EndDate: daysFromNow(7)}); err == nil {
+		fmt.Println("Cars free after the maintenance:", found.Total)
+	}
+
+	// Paging through the fleet, most booked first
+	criteria := services.SearchCriteria{Sort: services.SortByPopularity, Descending: true, Limit: 1}
+	for page := 1; ; page++ {
+		found, err := rentalSystem.SearchCars(criteria)
+		if err != nil {
+			fmt.Println("Search failed:", err)
+			break
+		}
+		for _, car := range found.Cars {
+			fmt.Printf("Page %d: %s %s\n", page, car.Make, car.Model)
+		}
+		if found.NextCursor == "" {
+			break
+		}
+		criteria.Cursor = found.NextCursor
+	}
+
+	// Child seats are limited per branch; the damage waiver is not
+	rentalSystem.AddExtra(models.Extra{Code: "child_seat", Name: "Child seat", Kind: models.ExtraEquipment, Pricing: models.ExtraPerDay, Price: money.New(8_00, money.USD),
+		MaxPerReservation: 2, Stocked: true, Stock: []models.BranchStock{{BranchID: downtown.ID, Quantity: 2}}})
+	rentalSystem.AddExtra(models.Extra{Code: "cdw", Name: "Collision damage waiver", Kind: models.ExtraInsurance, Pricing: models.ExtraPerDay, Price: money.New(18_00, money.USD)})
+	withSeats := services.ReservationRequest{
+		CustomerID: customer.ID, CarID: 1, StartDate: daysFromNow(30), EndDate: daysFromNow(33),
+		Extras: []services.ExtraRequest{{Code: "child_seat", Quantity: 2}, {Code: "cdw", Quantity: 1}},
+	}
+	if res, err := rentalSystem.CreateReservation(withSeats); err == nil {
+		fmt.Printf("Reservation %d with extras, total %s\n", res.ID, res.TotalPrice)
+	}
+	withSeats.CarID, withSeats.VehicleClass, withSeats.PickupBranchID = 0, "economy", downtown.ID
+	if _, err := rentalSystem.CreateReservation(withSeats); err != nil {
+		fmt.Println("Second booking with child seats rejected:", err)
+	}
+
+	// Picking the car up and bringing it back with fewer litres and more
+	// kilometres than allowed
+	if today, err := rentalSystem.CreateReservation(services.ReservationRequest{
+		CustomerID: customer.ID, CarID: 1, StartDate: daysFromNow(0), EndDate: daysFromNow(1),
+	}); err == nil {
+		if _, err := rentalSystem.CheckOut(today.ID, services.HandoverReport{Odometer: 12000, EnergyLevel: 100}); err != nil {
+			fmt.Println("Pickup failed:", err)
+		} else if result, err := rentalSystem.CheckIn(today.ID, services.HandoverReport{Odometer: 12600, EnergyLevel: 60, DamageNotes: "scratch on rear bumper"}); err == nil {
+			for _, charge := range result.Charges {
+				fmt.Printf("Return charge: %s %d x %s = %s\n", charge.Description, charge.Quantity, charge.UnitPrice, charge.Amount)
+			}
+			fmt.Printf("Reservation %d %s, amount due %s\n", result.Reservation.ID, result.Reservation.Status, result.AmountDue)
+		}
+
+		// Settling the completed rental earns loyalty points, which pay
+		// for part of the next booking
+		if _, err := rentalSystem.ProcessPayment(today.ID, money.Money{}, "card"); err == nil {
+			if account, err := rentalSystem.GetLoyaltyAccount(customer.ID); err == nil {
+				fmt.Printf("%s has %d points (%s)\n", customer.Name, account.Points, account.Tier)
+				if res, err := rentalSystem.CreateReservation(services.ReservationRequest{
+					CustomerID: customer.ID, CarID: 1, StartDate: daysFromNow(40), EndDate: daysFromNow(42), RedeemPoints: account.Points,
+				}); err == nil {
+					fmt.Printf("Reservation %d redeems %d points for %s off, total %s\n", res.ID, res.PointsRedeemed, res.LoyaltyDiscount, res.TotalPrice)
+				}
+			}
+		}
+	}
+
+	// A campaign code good for one booking of three days or more
+	if _, err := rentalSystem.AddPromoCode(models.PromoCode{
+		Code: "spring10", Description: "Spring campaign", Kind: models.DiscountPercent, Value: 10, MinDays: 3, MaxUses: 1,
+	}); err != nil {
+		fmt.Println("Error adding promo code:", err)
+	} else {
+		if res, err := rentalSystem.CreateReservation(services.ReservationRequest{
+			CustomerID: customer.ID, CarID: 1, StartDate: daysFromNow(50), EndDate: daysFromNow(53), PromoCode: "spring10",
+		}); err == nil {
+			fmt.Printf("Reservation %d booked with %s, total %s\n", res.ID, res.Promo.Code, res.TotalPrice)
+		}
+		if _, err := rentalSystem.CreateReservation(services.ReservationRequest{
+			CustomerID: customer.ID, CarID: 1, StartDate: daysFromNow(60), EndDate: daysFromNow(63), PromoCode: "SPRING10",
+		}); err != nil {
+			fmt.Println("Second booking with SPRING10 rejected:", err)
+		}
+	}
+
+	// Renting abroad: the London branch charges in pounds, converting the
+	// dollar rate card and extras at the rates of the booking day
+	london, err := rentalSystem.AddBranch(models.Branch{Name: "London", Currency: money.GBP, OpeningHours: openDaily("07:00", "22:00")})
+	if err == nil {
+		rentalSystem.AddCar(models.Car{ID: 3, Make: "Vauxhall", Model: "Corsa", Year: 2022, LicensePlate: "LN22ABC", Class: "economy", BranchID: london.ID,
+			RentalPricePerDay: money.New(40_00, money.USD)})
+		if res, err := rentalSystem.CreateReservation(services.ReservationRequest{
+			CustomerID: customer.ID, CarID: 3, StartDate: daysFromNow(70), EndDate: daysFromNow(72), PickupBranchID: london.ID,
+			Extras: []services.ExtraRequest{{Code: "cdw", Quantity: 1}},
+		}); err == nil {
+			fmt.Printf("Reservation %d in London, total %s\n", res.ID, res.TotalPrice)
+			if inEuros, err := rentalSystem.ConvertAmount(res.TotalPrice, money.EUR); err == nil {
+				fmt.Printf("That is about %s\n", inEuros)
+			}
+			if _, err := rentalSystem.ProcessPayment(res.ID, money.New(10_00, money.USD), "card"); err != nil {
+				fmt.Println("Payment in dollars rejected:", err)
+			}
+		} else {
+			fmt.Println("Reservation in London failed:", err)
+		}
+	}
+
+	fmt.Println("Events published:", published)
+}
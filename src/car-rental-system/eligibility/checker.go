@@ -0,0 +1,43 @@
+package eligibility
+
+import (
+	models "car-rental-system/rental_system_models"
+	"time"
+)
+
+// Request describes who wants to drive which car, and when.
+type Request struct {
+	Car      models.Car
+	Customer models.Customer
+	Start    time.Time
+	End      time.Time
+}
+
+// Rule rejects a request by returning one of the errors in this package.
+type Rule interface {
+	Check(req Request) error
+}
+
+// Checker runs its rules in order and stops at the first rejection.
+type Checker struct {
+	rules []Rule
+}
+
+func NewChecker(rules ...Rule) *Checker {
+	return &Checker{rules: rules}
+}
+
+// DefaultChecker checks the license format and expiry but sets no age
+// limits.
+func DefaultChecker() *Checker {
+	return NewChecker(LicenseFormat{Formats: DefaultLicenseFormats()}, LicenseExpiry{})
+}
+
+func (c *Checker) Check(req Request) error {
+	for _, rule := range c.rules {
+		if err := rule.Check(req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package eligibility
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Every rejection wraps one of these, so callers can tell the reasons apart
+// with errors.Is and get the details with errors.As.
+var (
+	ErrInvalidLicense       = errors.New("driver's license is not valid")
+	ErrLicenseExpired       = errors.New("driver's license expires before the rental ends")
+	ErrDriverAge            = errors.New("driver's age is outside the limits for this vehicle class")
+	ErrLicenseBlocked       = errors.New("driver's license is blocked")
+	ErrMissingDriverDetails = errors.New("driver details are incomplete")
+)
+
+// LicenseFormatError reports a license number that does not match the
+// format used where it was issued.
+type LicenseFormatError struct {
+	License string
+	Region  string
+}
+
+func (e *LicenseFormatError) Error() string {
+	return fmt.Sprintf("%v: %q is not a %s license number", ErrInvalidLicense, e.License, e.Region)
+}
+
+func (e *LicenseFormatError) Unwrap() error { return ErrInvalidLicense }
+
+// LicenseExpiredError reports a license that runs out before the car is
+// due back.
+type LicenseExpiredError struct {
+	Expiry    time.Time
+	RentalEnd time.Time
+}
+
+func (e *LicenseExpiredError) Error() string {
+	return fmt.Sprintf("%v: it expires on %s, the rental ends on %s",
+		ErrLicenseExpired, e.Expiry.Format(time.DateOnly), e.RentalEnd.Format(time.DateOnly))
+}
+
+func (e *LicenseExpiredError) Unwrap() error { return ErrLicenseExpired }
+
+// AgeError reports a driver who is too young or too old for the class of
+// car. A zero MinAge or MaxAge means there is no limit on that side.
+type AgeError struct {
+	Class  string
+	Age    int
+	MinAge int
+	MaxAge int
+}
+
+func (e *AgeError) Error() string {
+	class := e.Class
+	if class == "" {
+		class = "this car"
+	}
+	if e.MinAge > 0 && e.Age < e.MinAge {
+		return fmt.Sprintf("%v: drivers of %s must be at least %d, driver is %d", ErrDriverAge, class, e.MinAge, e.Age)
+	}
+	return fmt.Sprintf("%v: drivers of %s must be at most %d, driver is %d", ErrDriverAge, class, e.MaxAge, e.Age)
+}
+
+func (e *AgeError) Unwrap() error { return ErrDriverAge }
+
+// BlockedError reports a license on the blocklist.
+type BlockedError struct {
+	License string
+	Reason  string
+}
+
+func (e *BlockedError) Error() string {
+	if e.Reason == "" {
+		return ErrLicenseBlocked.Error()
+	}
+	return fmt.Sprintf("%v: %s", ErrLicenseBlocked, e.Reason)
+}
+
+func (e *BlockedError) Unwrap() error { return ErrLicenseBlocked }
+
+// MissingDetailsError reports a customer field a check needs but that was
+// never filled in.
+type MissingDetailsError struct {
+	Field string
+}
+
+func (e *MissingDetailsError) Error() string {
+	return fmt.Sprintf("%v: %s is required", ErrMissingDriverDetails, e.Field)
+}
+
+func (e *MissingDetailsError) Unwrap() error { return ErrMissingDriverDetails }
@@ -0,0 +1,92 @@
+package eligibility
+
+import (
+	"car-rental-system/pricing"
+	"regexp"
+	"strings"
+)
+
+// DefaultLicenseFormats covers the regions the counter sees most. Regions
+// are ISO country codes, with the state appended for countries that issue
+// licenses per state.
+func DefaultLicenseFormats() map[string]*regexp.Regexp {
+	return map[string]*regexp.Regexp{
+		"US-CA": regexp.MustCompile(`^[A-Z][0-9]{7}$`),
+		"US-NY": regexp.MustCompile(`^[0-9]{9}$`),
+		"US-TX": regexp.MustCompile(`^[0-9]{8}$`),
+		"US-FL": regexp.MustCompile(`^[A-Z][0-9]{12}$`),
+		"CA-ON": regexp.MustCompile(`^[A-Z][0-9]{14}$`),
+		"GB":    regexp.MustCompile(`^[A-Z9]{5}[0-9]{6}[A-Z9]{2}[0-9][A-Z]{2}$`),
+		"DE":    regexp.MustCompile(`^[A-Z0-9]{11}$`),
+		"FR":    regexp.MustCompile(`^[0-9]{12}$`),
+	}
+}
+
+// LicenseFormat checks the license number against the format of the region
+// that issued it. Dashes are ignored. Customers without a region are
+// rejected; regions without a known format are accepted as they are.
+type LicenseFormat struct {
+	Formats map[string]*regexp.Regexp
+}
+
+func (l LicenseFormat) Check(req Request) error {
+	region := strings.ToUpper(req.Customer.LicenseRegion)
+	if region == "" {
+		return &MissingDetailsError{Field: "license region"}
+	}
+	format, known := l.Formats[region]
+	if !known {
+		return nil
+	}
+	license := strings.ReplaceAll(req.Customer.DriversLicense, "-", "")
+	if !format.MatchString(license) {
+		return &LicenseFormatError{License: req.Customer.DriversLicense, Region: region}
+	}
+	return nil
+}
+
+// LicenseExpiry requires the license to be valid until the car is returned.
+type LicenseExpiry struct{}
+
+func (LicenseExpiry) Check(req Request) error {
+	expiry := req.Customer.LicenseExpiry
+	if expiry.IsZero() {
+		return &MissingDetailsError{Field: "license expiry"}
+	}
+	if expiry.Before(req.End) {
+		return &LicenseExpiredError{Expiry: expiry, RentalEnd: req.End}
+	}
+	return nil
+}
+
+// AgeRange bounds a driver's age in full years. Zero means no limit.
+type AgeRange struct {
+	Min int
+	Max int
+}
+
+// AgeLimits checks the driver's age on the first day of the rental against
+// the range set for the car's class, or Default for classes not listed.
+type AgeLimits struct {
+	Classes map[string]AgeRange
+	Default AgeRange
+}
+
+func (a AgeLimits) Check(req Request) error {
+	limits, ok := a.Classes[req.Car.Class]
+	if !ok {
+		limits = a.Default
+	}
+	if limits == (AgeRange{}) {
+		return nil
+	}
+	if req.Customer.DateOfBirth.IsZero() {
+		return &MissingDetailsError{Field: "date of birth"}
+	}
+
+	age := pricing.AgeOn(req.Customer.DateOfBirth, req.Start)
+	if (limits.Min > 0 && age < limits.Min) || (limits.Max > 0 && age > limits.Max) {
+		return &AgeError{Class: req.Car.Class, Age: age, MinAge: limits.Min, MaxAge: limits.Max}
+	}
+	return nil
+}
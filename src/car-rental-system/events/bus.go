@@ -0,0 +1,146 @@
+package events
+
+import (
+	"slices"
+	"sync"
+)
+
+// Subscriber reacts to published events.
+type Subscriber interface {
+	Handle(Event)
+}
+
+// SubscriberFunc lets a plain function be used as a Subscriber.
+type SubscriberFunc func(Event)
+
+func (f SubscriberFunc) Handle(e Event) { f(e) }
+
+// subscription delivers events to one subscriber, either straight away or
+// through its own queue and goroutine.
+type subscription struct {
+	id         int
+	subscriber Subscriber
+
+	mu     sync.Mutex
+	queue  chan Event // nil for synchronous subscribers
+	closed bool
+}
+
+func (s *subscription) deliver(e Event) {
+	s.mu.Lock()
+	if s.queue == nil {
+		// Handle runs unlocked so a subscriber may unsubscribe itself.
+		closed := s.closed
+		s.mu.Unlock()
+		if !closed {
+			s.subscriber.Handle(e)
+		}
+		return
+	}
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.queue <- e
+	}
+}
+
+func (s *subscription) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.queue != nil && !s.closed {
+		close(s.queue)
+	}
+	s.closed = true
+}
+
+// Bus fans events out to its subscribers in the order they are published.
+//
+// Synchronous subscribers run in the publisher's goroutine, so Publish
+// returns only once they are done. Asynchronous subscribers each get a
+// queue and a goroutine of their own: they see events in order but later,
+// and Publish blocks only when a subscriber's queue is full.
+//
+// Publish makes no promise about whoever raised the event: a publisher
+// such as the rental system may hand its events to another goroutine to
+// keep them in order, so a synchronous subscriber can run after the call
+// that raised the event has returned.
+type Bus struct {
+	mu sync.RWMutex
+	// subscriptions is kept in the order subscribers signed up, which is
+	// the order they are called in.
+	subscriptions []*subscription
+	nextID        int
+	closed        bool
+	wg            sync.WaitGroup
+}
+
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe registers a synchronous subscriber and returns the function
+// that removes it again.
+func (b *Bus) Subscribe(subscriber Subscriber) (unsubscribe func()) {
+	return b.add(&subscription{subscriber: subscriber})
+}
+
+// SubscribeAsync registers a subscriber that handles events in its own
+// goroutine, with room for buffer events that have not been handled yet.
+// Unsubscribing stops delivery; events already queued are still handled.
+func (b *Bus) SubscribeAsync(subscriber Subscriber, buffer int) (unsubscribe func()) {
+	sub := &subscription{subscriber: subscriber, queue: make(chan Event, max(buffer, 0))}
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		for e := range sub.queue {
+			subscriber.Handle(e)
+		}
+	}()
+	return b.add(sub)
+}
+
+func (b *Bus) add(sub *subscription) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		sub.close()
+		return func() {}
+	}
+	sub.id = b.nextID
+	b.nextID++
+	b.subscriptions = append(b.subscriptions, sub)
+	return func() {
+		b.mu.Lock()
+		b.subscriptions = slices.DeleteFunc(b.subscriptions, func(s *subscription) bool { return s.id == sub.id })
+		b.mu.Unlock()
+		sub.close()
+	}
+}
+
+// Publish hands e to every subscriber. Events published after Close are
+// dropped.
+func (b *Bus) Publish(e Event) {
+	b.mu.RLock()
+	subs := slices.Clone(b.subscriptions)
+	b.mu.RUnlock()
+
+	// The lock is not held while delivering, so subscribers may subscribe,
+	// unsubscribe or publish themselves.
+	for _, sub := range subs {
+		sub.deliver(e)
+	}
+}
+
+// Close stops delivery and waits for the asynchronous subscribers to work
+// through the events they have queued.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	b.closed = true
+	subs := b.subscriptions
+	b.subscriptions = nil
+	b.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.close()
+	}
+	b.wg.Wait()
+}
@@ -0,0 +1,149 @@
+// Package events carries the rental system's domain events to whatever
+// wants to react to them: notifications, analytics, audit logs.
+package events
+
+import (
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"time"
+)
+
+// Event is something that happened to the rental system. Events hold
+// copies of the records involved as they were right after the change.
+type Event interface {
+	// Name identifies the kind of event, e.g. "reservation.created".
+	Name() string
+	// OccurredAt is when the change was made, by the rental system's clock.
+	OccurredAt() time.Time
+}
+
+const (
+	NameReservationCreated   = "reservation.created"
+	NameReservationModified  = "reservation.modified"
+	NameReservationCancelled = "reservation.cancelled"
+	NameReservationPickedUp  = "reservation.picked_up"
+	NameReservationCompleted = "reservation.completed"
+	NamePaymentProcessed     = "payment.processed"
+	NameCarAdded             = "car.added"
+	NameWaitlistOfferMade    = "waitlist.offer_made"
+	NameWaitlistOfferExpired = "waitlist.offer_expired"
+	NameHoldPlaced           = "hold.placed"
+	NameHoldExpired          = "hold.expired"
+	NameLoyaltyPointsEarned  = "loyalty.points_earned"
+)
+
+type ReservationCreated struct {
+	Reservation models.Reservation `json:"reservation"`
+	At          time.Time          `json:"at"`
+}
+
+func (e ReservationCreated) Name() string          { return NameReservationCreated }
+func (e ReservationCreated) OccurredAt() time.Time { return e.At }
+
+// ReservationModified is published when a reservation's dates or extras
+// change, or a car is assigned to a class-only booking.
+type ReservationModified struct {
+	Reservation models.Reservation `json:"reservation"`
+	Previous    models.Reservation `json:"previous"`
+	At          time.Time          `json:"at"`
+}
+
+func (e ReservationModified) Name() string          { return NameReservationModified }
+func (e ReservationModified) OccurredAt() time.Time { return e.At }
+
+type ReservationCancelled struct {
+	Reservation models.Reservation `json:"reservation"`
+	Fee         money.Money        `json:"fee"`
+	Refund      money.Money        `json:"refund"`
+	At          time.Time          `json:"at"`
+}
+
+func (e ReservationCancelled) Name() string          { return NameReservationCancelled }
+func (e ReservationCancelled) OccurredAt() time.Time { return e.At }
+
+// ReservationPickedUp is published when the customer collects the car.
+type ReservationPickedUp struct {
+	Reservation models.Reservation `json:"reservation"`
+	At          time.Time          `json:"at"`
+}
+
+func (e ReservationPickedUp) Name() string          { return NameReservationPickedUp }
+func (e ReservationPickedUp) OccurredAt() time.Time { return e.At }
+
+// ReservationCompleted is published when the car is returned. Charges are
+// the usage fees added to the reservation on return.
+type ReservationCompleted struct {
+	Reservation models.Reservation `json:"reservation"`
+	Charges     []models.PriceLine `json:"charges"`
+	At          time.Time          `json:"at"`
+}
+
+func (e ReservationCompleted) Name() string          { return NameReservationCompleted }
+func (e ReservationCompleted) OccurredAt() time.Time { return e.At }
+
+// PaymentProcessed is published once money has been taken, whether in one
+// step or by capturing an authorization.
+type PaymentProcessed struct {
+	Payment     models.Payment     `json:"payment"`
+	Reservation models.Reservation `json:"reservation"`
+	At          time.Time          `json:"at"`
+}
+
+func (e PaymentProcessed) Name() string          { return NamePaymentProcessed }
+func (e PaymentProcessed) OccurredAt() time.Time { return e.At }
+
+type CarAdded struct {
+	Car models.Car `json:"car"`
+	At  time.Time  `json:"at"`
+}
+
+func (e CarAdded) Name() string          { return NameCarAdded }
+func (e CarAdded) OccurredAt() time.Time { return e.At }
+
+// WaitlistOfferMade is published when a waitlisted customer is offered the
+// car or class they were waiting for, held for them until
+// Entry.OfferExpiresAt.
+type WaitlistOfferMade struct {
+	Entry models.WaitlistEntry `json:"entry"`
+	At    time.Time            `json:"at"`
+}
+
+func (e WaitlistOfferMade) Name() string          { return NameWaitlistOfferMade }
+func (e WaitlistOfferMade) OccurredAt() time.Time { return e.At }
+
+type WaitlistOfferExpired struct {
+	Entry models.WaitlistEntry `json:"entry"`
+	At    time.Time            `json:"at"`
+}
+
+func (e WaitlistOfferExpired) Name() string          { return NameWaitlistOfferExpired }
+func (e WaitlistOfferExpired) OccurredAt() time.Time { return e.At }
+
+type HoldPlaced struct {
+	Hold models.Hold `json:"hold"`
+	At   time.Time   `json:"at"`
+}
+
+func (e HoldPlaced) Name() string          { return NameHoldPlaced }
+func (e HoldPlaced) OccurredAt() time.Time { return e.At }
+
+// HoldExpired is published when a hold runs out without being confirmed
+// or released.
+type HoldExpired struct {
+	Hold models.Hold `json:"hold"`
+	At   time.Time   `json:"at"`
+}
+
+func (e HoldExpired) Name() string          { return NameHoldExpired }
+func (e HoldExpired) OccurredAt() time.Time { return e.At }
+
+// LoyaltyPointsEarned is published when a completed, paid rental earns
+// its points. Tier is the customer's tier counting this rental.
+type LoyaltyPointsEarned struct {
+	Entry models.LoyaltyEntry `json:"entry"`
+	Tier  models.LoyaltyTier  `json:"tier"`
+	At    time.Time           `json:"at"`
+}
+
+func (e LoyaltyPointsEarned) Name() string          { return NameLoyaltyPointsEarned }
+func (e LoyaltyPointsEarned) OccurredAt() time.Time { return e.At }
@@ -0,0 +1,283 @@
+package services
+
+import (
+	models "car-rental-system/rental_system_models"
+	"sort"
+	"time"
+)
+
+// booking is a half-open [start, end) window during which a car is taken,
+// so a rental may start on the same day the previous one ends. It belongs
+// to a reservation, a hold, a maintenance window or a waitlist offer
+// holding the car; the other IDs are zero. Rentals record the branches the
+// car leaves from and ends up at.
+type booking struct {
+	reservationID int
+	holdID        int
+	maintenanceID int
+	waitlistID    int
+	start         time.Time
+	end           time.Time
+	fromBranch    int
+	toBranch      int
+}
+
+// rental reports whether the booking takes the car out on the road: a
+// reservation, or a hold that is about to become one.
+func (b booking) rental() bool {
+	return b.reservationID != 0 || b.holdID != 0
+}
+
+func (b booking) overlaps(start, end time.Time) bool {
+	return b.start.Before(end) && start.Before(b.end)
+}
+
+// carCalendar keeps a car's bookings ordered by start time.
+type carCalendar struct {
+	bookings []booking
+}
+
+func (c *carCalendar) add(b booking) {
+	i := sort.Search(len(c.bookings), func(i int) bool {
+		return c.bookings[i].start.After(b.start)
+	})
+	c.bookings = append(c.bookings, booking{})
+	copy(c.bookings[i+1:], c.bookings[i:])
+	c.bookings[i] = b
+}
+
+// remove drops the first booking matching.
+func (c *carCalendar) remove(matches func(booking) bool) {
+	for i, b := range c.bookings {
+		if matches(b) {
+			c.bookings = append(c.bookings[:i], c.bookings[i+1:]...)
+			return
+		}
+	}
+}
+
+// conflicts returns the bookings overlapping [start, end), skipping the
+// reservation given in ignoreID. Pass 0 to skip nothing.
+func (c *carCalendar) conflicts(start, end time.Time, ignoreID int) []booking {
+	var found []booking
+	for _, b := range c.bookings {
+		if !b.start.Before(end) {
+			break
+		}
+		if (ignoreID == 0 || b.reservationID != ignoreID) && b.overlaps(start, end) {
+			found = append(found, b)
+		}
+	}
+	return found
+}
+
+// classHold keeps a place in a vehicle class for a waitlist offer.
+type classHold struct {
+	class string
+	start time.Time
+	end   time.Time
+}
+
+// heldExtras are the extras a hold or a waitlist offer keeps back at its
+// pickup branch.
+type heldExtras struct {
+	branchID int
+	start    time.Time
+	end      time.Time
+	extras   []models.ReservationExtra
+}
+
+// extrasHolder is a hold or a waitlist offer, whichever ID is set.
+type extrasHolder struct {
+	holdID     int
+	waitlistID int
+}
+
+// availabilityCalendar indexes bookings per car, the places held in
+// vehicle classes by waitlist offers and the extras held by holds and
+// offers.
+type availabilityCalendar struct {
+	cars       map[int]*carCalendar
+	classHolds map[int]classHold
+	extraHolds map[extrasHolder]heldExtras
+}
+
+func newAvailabilityCalendar() *availabilityCalendar {
+	return &availabilityCalendar{
+		cars:       make(map[int]*carCalendar),
+		classHolds: make(map[int]classHold),
+		extraHolds: make(map[extrasHolder]heldExtras),
+	}
+}
+
+func (ac *availabilityCalendar) calendar(carID int) *carCalendar {
+	cal, exists := ac.cars[carID]
+	if !exists {
+		cal = &carCalendar{}
+		ac.cars[carID] = cal
+	}
+	return cal
+}
+
+func (ac *availabilityCalendar) book(res *models.Reservation) {
+	ac.calendar(res.CarID).add(booking{
+		reservationID: res.ID,
+		start:         res.StartDate,
+		end:           res.EndDate,
+		fromBranch:    res.PickupBranchID,
+		toBranch:      res.DropoffBranchID,
+	})
+}
+
+func (ac *availabilityCalendar) release(carID, reservationID int) {
+	if cal, exists := ac.cars[carID]; exists {
+		cal.remove(func(b booking) bool { return b.reservationID == reservationID })
+	}
+}
+
+// block keeps the car free of rentals for a maintenance window.
+func (ac *availabilityCalendar) block(carID, maintenanceID int, start, end time.Time) {
+	ac.calendar(carID).add(booking{maintenanceID: maintenanceID, start: start, end: end})
+}
+
+func (ac *availabilityCalendar) unblock(carID, maintenanceID int) {
+	if cal, exists := ac.cars[carID]; exists {
+		cal.remove(func(b booking) bool { return b.maintenanceID == maintenanceID })
+	}
+}
+
+// hold blocks the car for a hold just as book does for a reservation, and
+// keeps its extras back.
+func (ac *availabilityCalendar) hold(h *models.Hold) {
+	ac.calendar(h.CarID).add(booking{
+		holdID:     h.ID,
+		start:      h.StartDate,
+		end:        h.EndDate,
+		fromBranch: h.PickupBranchID,
+		toBranch:   h.DropoffBranchID,
+	})
+	if len(h.Extras) > 0 {
+		ac.extraHolds[extrasHolder{holdID: h.ID}] = heldExtras{branchID: h.PickupBranchID, start: h.StartDate, end: h.EndDate, extras: h.Extras}
+	}
+}
+
+func (ac *availabilityCalendar) releaseHold(h *models.Hold) {
+	delete(ac.extraHolds, extrasHolder{holdID: h.ID})
+	if cal, exists := ac.cars[h.CarID]; exists {
+		cal.remove(func(b booking) bool { return b.holdID == h.ID })
+	}
+}
+
+// holdOffer keeps the entry's car, or a place in its class, free for the
+// customer while the offer is open, together with the extras they asked
+// for.
+func (ac *availabilityCalendar) holdOffer(entry *models.WaitlistEntry) {
+	if len(entry.Extras) > 0 {
+		ac.extraHolds[extrasHolder{waitlistID: entry.ID}] = heldExtras{branchID: entry.PickupBranchID, start: entry.StartDate, end: entry.EndDate, extras: entry.Extras}
+	}
+	if entry.CarID == 0 {
+		ac.classHolds[entry.ID] = classHold{class: entry.VehicleClass, start: entry.StartDate, end: entry.EndDate}
+		return
+	}
+	ac.calendar(entry.CarID).add(booking{waitlistID: entry.ID, start: entry.StartDate, end: entry.EndDate})
+}
+
+func (ac *availabilityCalendar) releaseOffer(entry *models.WaitlistEntry) {
+	delete(ac.classHolds, entry.ID)
+	delete(ac.extraHolds, extrasHolder{waitlistID: entry.ID})
+	if cal, exists := ac.cars[entry.CarID]; exists {
+		cal.remove(func(b booking) bool { return b.waitlistID == entry.ID })
+	}
+}
+
+// classHoldsDuring counts the places held in the class over [start, end).
+func (ac *availabilityCalendar) classHoldsDuring(class string, start, end time.Time) int {
+	count := 0
+	for _, h := range ac.classHolds {
+		if h.class == class && h.start.Before(end) && start.Before(h.end) {
+			count++
+		}
+	}
+	return count
+}
+
+// extrasHeld returns the units of an extra that holds and offers keep back
+// at the branch.
+func (ac *availabilityCalendar) extrasHeld(code string, branchID int) []extraUse {
+	var uses []extraUse
+	for _, held := range ac.extraHolds {
+		if held.branchID != branchID {
+			continue
+		}
+		for _, extra := range held.extras {
+			if extra.Code == code {
+				uses = append(uses, extraUse{start: held.start, end: held.end, quantity: extra.Quantity})
+			}
+		}
+	}
+	return uses
+}
+
+// reservationsDuring returns the IDs of the reservations overlapping
+// [start, end).
+func (ac *availabilityCalendar) reservationsDuring(carID int, start, end time.Time) []int {
+	cal, exists := ac.cars[carID]
+	if !exists {
+		return nil
+	}
+	var ids []int
+	for _, b := range cal.conflicts(start, end, 0) {
+		if b.reservationID != 0 {
+			ids = append(ids, b.reservationID)
+		}
+	}
+	return ids
+}
+
+// isFree reports whether the car has no booking overlapping [start, end)
+// other than ignoreID. Pass 0 to consider every booking.
+func (ac *availabilityCalendar) isFree(carID int, start, end time.Time, ignoreID int) bool {
+	cal, exists := ac.cars[carID]
+	if !exists {
+		return true
+	}
+	return len(cal.conflicts(start, end, ignoreID)) == 0
+}
+
+// locationAt returns the branch the car will be parked at on at: where the
+// last rental or hold ending by then drops it off, or home when there is
+// none. The reservation given in ignoreID is left out.
+func (ac *availabilityCalendar) locationAt(carID, home int, at time.Time, ignoreID int) int {
+	cal, exists := ac.cars[carID]
+	if !exists {
+		return home
+	}
+	location := home
+	var latest time.Time
+	for _, b := range cal.bookings {
+		if !b.rental() || (ignoreID != 0 && b.reservationID == ignoreID) || b.toBranch == 0 {
+			continue
+		}
+		if !b.end.After(at) && !b.end.Before(latest) {
+			location, latest = b.toBranch, b.end
+		}
+	}
+	return location
+}
+
+// nextPickup returns the branch of the first rental or hold starting at
+// or after from, and false when there is none. The reservation given in
+// ignoreID is left out.
+func (ac *availabilityCalendar) nextPickup(carID int, from time.Time, ignoreID int) (int, bool) {
+	cal, exists := ac.cars[carID]
+	if !exists {
+		return 0, false
+	}
+	for _, b := range cal.bookings {
+		if !b.rental() || (ignoreID != 0 && b.reservationID == ignoreID) || b.start.Before(from) {
+			continue
+		}
+		return b.fromBranch, true
+	}
+	return 0, false
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"car-rental-system/repository"
+	"errors"
+	"strings"
+	"time"
+)
+
+func findBranch(repo repository.Repository, branchID int) (*models.Branch, error) {
+	branch, err := repo.Branch(branchID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrBranchNotFound
+	}
+	return branch, err
+}
+
+func validateBranch(branch *models.Branch) error {
+	branch.Name = strings.TrimSpace(branch.Name)
+	if branch.Name == "" {
+		return ErrInvalidBranch
+	}
+	if branch.Currency != "" {
+		currency, err := money.ParseCurrency(string(branch.Currency))
+		if err != nil {
+			return err
+		}
+		branch.Currency = currency
+	}
+	for _, hours := range branch.OpeningHours {
+		open, err := time.Parse("15:04", hours.Open)
+		if err != nil {
+			return ErrInvalidBranch
+		}
+		closing, err := time.Parse("15:04", hours.Close)
+		if err != nil || !closing.After(open) || hours.Weekday < time.Sunday || hours.Weekday > time.Saturday {
+			return ErrInvalidBranch
+		}
+	}
+	return nil
+}
+
+func (rs *RentalSystem) AddBranch(branch models.Branch) (*models.Branch, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	branch.ID = 0
+	if err := validateBranch(&branch); err != nil {
+		return nil, err
+	}
+	if err := rs.repo.SaveBranch(&branch); err != nil {
+		return nil, err
+	}
+	return &branch, nil
+}
+
+func (rs *RentalSystem) GetBranch(branchID int) (*models.Branch, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return findBranch(rs.repo, branchID)
+}
+
+func (rs *RentalSystem) ListBranches() ([]models.Branch, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return rs.repo.Branches()
+}
+
+// RelocateCar records that staff moved the car to another branch between
+// rentals. A branch ID of zero unties the car from any branch.
+func (rs *RentalSystem) RelocateCar(carID, branchID int) (*models.Car, error) {
+	rs.mu.Lock()
+	defer rs.unlock()
+
+	car, err := findCar(rs.repo, carID)
+	if err != nil {
+		return nil, err
+	}
+	if branchID != 0 {
+		if _, err := findBranch(rs.repo, branchID); err != nil {
+			return nil, err
+		}
+	}
+	car.BranchID = branchID
+	if err := rs.repo.SaveCar(car); err != nil {
+		return nil, err
+	}
+	// The car may now be where someone waiting wants to pick it up.
+	rs.offerWaitlist()
+	return car, nil
+}
+
+// resolveRoute fills in and checks the branches of a rental of car over
+// [start, end). Pickup defaults to wherever the car will be by then and
+// drop-off to the pickup branch. The car must be at the pickup branch, both
+// branches must be open at the times given, and leaving the car at the
+// drop-off branch must not strand the car's next rental. The reservation
+// given in ignoreID is left out, as when it is being modified.
+func (rs *RentalSystem) resolveRoute(car *models.Car, pickup, dropoff int, start, end time.Time, ignoreID int) (int, int, error) {
+	location := rs.calendar.locationAt(car.ID, car.BranchID, start, ignoreID)
+	if pickup == 0 {
+		pickup = location
+	}
+	if dropoff == 0 {
+		dropoff = pickup
+	}
+
+	if err := rs.checkBranchOpen(pickup, start); err != nil {
+		return 0, 0, err
+	}
+	if err := rs.checkBranchOpen(dropoff, end); err != nil {
+		return 0, 0, err
+	}
+	if location != 0 && pickup != location {
+		return 0, 0, ErrCarNotAtBranch
+	}
+	if next, ok := rs.calendar.nextPickup(car.ID, end, ignoreID); ok && next != 0 && next != dropoff {
+		return 0, 0, ErrCarNotAvailable
+	}
+	return pickup, dropoff, nil
+}
+
+// checkBranchOpen fails when the branch does not exist or is closed at t.
+// Branch zero stands for no branch and is always accepted.
+func (rs *RentalSystem) checkBranchOpen(branchID int, t time.Time) error {
+	if branchID == 0 {
+		return nil
+	}
+	branch, err := findBranch(rs.repo, branchID)
+	if err != nil {
+		return err
+	}
+	if !branch.IsOpenAt(t) {
+		return ErrBranchClosed
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"car-rental-system/events"
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"car-rental-system/repository"
+	"time"
+)
+
+// CancellationResult reports what cancelling a reservation cost.
+type CancellationResult struct {
+	ReservationID int         `json:"reservationId"`
+	Fee           money.Money `json:"fee"`
+	Refund        money.Money `json:"refund"`
+	// RefundPending is what the payment provider could not refund yet. It
+	// stays due on the reservation and can be given back with
+	// RefundPayment.
+	RefundPending money.Money `json:"refundPending"`
+	// AmountDue is the part of the fee not covered by earlier payments.
+	AmountDue money.Money `json:"amountDue"`
+}
+
+// cancellationFee applies policy to a cancellation made at now. Late fees
+// are rounded half up to the minor unit.
+func cancellationFee(policy models.CancellationPolicy, res *models.Reservation, now time.Time) money.Money {
+	freeUntil := res.StartDate.Add(-time.Duration(policy.FreeCancellationHours) * time.Hour)
+	switch {
+	case policy.NonRefundable:
+		return res.TotalPrice
+	case !now.After(freeUntil):
+		return money.New(0, res.TotalPrice.Currency)
+	default:
+		return res.TotalPrice.Percent(policy.LateFeePercent, money.HalfUp)
+	}
+}
+
+// policyFor picks the cancellation policy a new booking of car is made
+// under.
+func (rs *RentalSystem) policyFor(car *models.Car) models.CancellationPolicy {
+	if car.CancellationPolicy != (models.CancellationPolicy{}) {
+		return car.CancellationPolicy
+	}
+	return rs.cancellationPolicy
+}
+
+// CancelReservation cancels the reservation under the policy it was booked
+// with. Whatever was paid beyond the fee is refunded straight away; the
+// reservation is kept with its status set to cancelled.
+//
+// The cancellation is committed before the payment provider is asked to
+// release holds and refund, so a provider failure never undoes it: a
+// refund that fails is reported as pending and a hold that cannot be
+// released stays authorized.
+func (rs *RentalSystem) CancelReservation(reservationID int) (*CancellationResult, error) {
+	rs.mu.Lock()
+	defer rs.unlock()
+
+	res, err := findReservation(rs.repo, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	switch res.Status {
+	case models.ReservationCancelled:
+		return nil, ErrReservationCancelled
+	case models.ReservationInProgress, models.ReservationCompleted:
+		return nil, ErrAlreadyPickedUp
+	}
+
+	now := rs.now()
+	fee := cancellationFee(res.CancellationPolicy, res, now)
+	result := &CancellationResult{ReservationID: reservationID, Fee: fee}
+
+	err = rs.repo.Transaction(func(tx repository.Repository) error {
+		res.Status = models.ReservationCancelled
+		res.CancelledAt = &now
+		res.CancellationFee = fee
+		if err := settleBalance(res); err != nil {
+			return err
+		}
+		if err := tx.SaveReservation(res); err != nil {
+			return err
+		}
+		if err := rs.bill(tx, res, reasonReservationCancelled); err != nil {
+			return err
+		}
+		if err := rs.reinstatePoints(tx, res); err != nil {
+			return err
+		}
+		return rs.voidPromoUse(tx, res)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Provider failures leave the holds and the refund on record for staff
+	// to retry.
+	rs.voidAuthorizations(reservationID)
+	refundDue := res.RefundDue
+	if refundDue.IsPositive() {
+		rs.refundReservation(reservationID, refundDue)
+	}
+	settled, err := findReservation(rs.repo, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	*res = *settled
+	result.Refund = refundDue.Sub(res.RefundDue)
+	result.RefundPending = res.RefundDue
+	result.AmountDue = res.AmountDue
+
+	rs.calendar.release(res.CarID, res.ID)
+	rs.publish(events.ReservationCancelled{Reservation: *res, Fee: result.Fee, Refund: result.Refund, At: now})
+	rs.offerWaitlist()
+	return result, nil
+}
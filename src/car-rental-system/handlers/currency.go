@@ -0,0 +1,171 @@
+package services
+
+import (
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"car-rental-system/repository"
+	"math"
+	"time"
+)
+
+// CurrencyPolicy sets how amounts in different currencies are brought
+// together.
+type CurrencyPolicy struct {
+	// Base is the currency of amounts given without one, such as the price
+	// of a car added at a branch without a currency, and the one the
+	// loyalty ledger is kept in.
+	Base money.Currency
+	// RateDate picks the day whose exchange rates a reservation is
+	// converted at.
+	RateDate RateDate
+	// Rounding applies to every conversion.
+	Rounding money.Rounding
+}
+
+// RateDate is the day a reservation's amounts are converted at.
+type RateDate string
+
+const (
+	// RateAtBooking keeps the rates in force when the reservation was made
+	// for its whole life.
+	RateAtBooking RateDate = "booking"
+	// RateAtPickup uses the rates of the day the rental starts, and
+	// follows the reservation when it is moved.
+	RateAtPickup RateDate = "pickup"
+)
+
+// DefaultCurrencyPolicy keeps the books in US dollars and converts at the
+// rates in force when the reservation was made, rounding halves to even.
+func DefaultCurrencyPolicy() CurrencyPolicy {
+	return CurrencyPolicy{Base: money.USD, RateDate: RateAtBooking, Rounding: money.HalfEven}
+}
+
+// normalizeAmount checks an amount's currency, giving amounts without one
+// fallback, or the base currency when that is empty too.
+func (rs *RentalSystem) normalizeAmount(amount *money.Money, fallback money.Currency) error {
+	if amount.Currency == "" {
+		amount.Currency = fallback
+	}
+	if amount.Currency == "" {
+		amount.Currency = rs.currencyPolicy.Base
+	}
+	currency, err := money.ParseCurrency(string(amount.Currency))
+	if err != nil {
+		return err
+	}
+	amount.Currency = currency
+	return nil
+}
+
+// exchange converts at the rates of repo's table in force at at.
+func (rs *RentalSystem) exchange(repo repository.Repository, at time.Time) (money.Exchange, error) {
+	rates, err := repo.ExchangeRates()
+	if err != nil {
+		return money.Exchange{}, err
+	}
+	table := make(money.Rates, len(rates))
+	for i, rate := range rates {
+		table[i] = rate.Rate
+	}
+	return money.Exchange{Rates: table, At: at, Rounding: rs.currencyPolicy.Rounding}, nil
+}
+
+// convert converts amount into currency to at the rates in force at at.
+func (rs *RentalSystem) convert(repo repository.Repository, amount money.Money, to money.Currency, at time.Time) (money.Money, error) {
+	exchange, err := rs.exchange(repo, at)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return exchange.Convert(amount, to)
+}
+
+// setRateDate sets the day the reservation's amounts are converted at,
+// following the currency policy.
+func (rs *RentalSystem) setRateDate(res *models.Reservation) {
+	switch {
+	case rs.currencyPolicy.RateDate == RateAtPickup:
+		res.RateDate = res.StartDate
+	case res.RateDate.IsZero():
+		res.RateDate = rs.now()
+	}
+}
+
+// rentalCurrency is what the rental of car on res is charged in. A
+// reservation keeps the currency it was booked in, even when a car or
+// pickup branch assigned later uses another. A new one is charged in its
+// pickup branch's currency, or else the car's.
+func (rs *RentalSystem) rentalCurrency(car *models.Car, res *models.Reservation) (money.Currency, error) {
+	if res.TotalPrice.Currency != "" {
+		return res.TotalPrice.Currency, nil
+	}
+	if branchID := res.PickupBranchID; branchID != 0 {
+		branch, err := findBranch(rs.repo, branchID)
+		if err != nil {
+			return "", err
+		}
+		if branch.Currency != "" {
+			return branch.Currency, nil
+		}
+	}
+	return car.RentalPricePerDay.Currency, nil
+}
+
+// BaseCurrency is the currency amounts given without one are in.
+func (rs *RentalSystem) BaseCurrency() money.Currency {
+	return rs.currencyPolicy.Base
+}
+
+// AddExchangeRate adds a rate to the exchange-rate table. It takes over
+// from the pair's earlier rates on its effective date, now when left out.
+// Reservations already converted at an earlier date keep their prices.
+func (rs *RentalSystem) AddExchangeRate(rate money.Rate) (*models.ExchangeRate, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	from, err := money.ParseCurrency(string(rate.From))
+	if err != nil {
+		return nil, err
+	}
+	to, err := money.ParseCurrency(string(rate.To))
+	if err != nil {
+		return nil, err
+	}
+	if from == to || !(rate.Value > 0) || math.IsInf(rate.Value, 0) {
+		return nil, ErrInvalidExchangeRate
+	}
+	rate.From, rate.To = from, to
+	now := rs.now()
+	if rate.EffectiveFrom.IsZero() {
+		rate.EffectiveFrom = now
+	}
+
+	entry := &models.ExchangeRate{Rate: rate, CreatedAt: now}
+	if err := rs.repo.SaveExchangeRate(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// ListExchangeRates returns the exchange-rate table in the order the
+// rates were added.
+func (rs *RentalSystem) ListExchangeRates() ([]models.ExchangeRate, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return rs.repo.ExchangeRates()
+}
+
+// ConvertAmount converts amount into another currency at the rates in
+// force now, e.g. to show a customer prices in their own currency.
+func (rs *RentalSystem) ConvertAmount(amount money.Money, to money.Currency) (money.Money, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	currency, err := money.ParseCurrency(string(to))
+	if err != nil {
+		return money.Money{}, err
+	}
+	if err := rs.normalizeAmount(&amount, ""); err != nil {
+		return money.Money{}, err
+	}
+	return rs.convert(rs.repo, amount, currency, rs.now())
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"errors"
+	"testing"
+)
+
+func TestReservationKeepsBookedCurrency(t *testing.T) {
+	rs, customer := newTestSystem(t)
+	paris, err := rs.AddBranch(models.Branch{Name: "Paris", Currency: money.EUR})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rs.AddExchangeRate(money.Rate{From: money.USD, To: money.EUR, Value: 0.9}); err != nil {
+		t.Fatal(err)
+	}
+	if err := rs.AddCar(models.Car{ID: 3, Make: "Renault", Model: "Clio", Year: 2022, LicensePlate: "PA123", RentalPricePerDay: money.New(30_00, money.EUR), Class: "economy", BranchID: paris.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rs.AddExtra(models.Extra{Code: "cdw", Name: "Collision damage waiver", Kind: models.ExtraInsurance, Pricing: models.ExtraPerDay, Price: money.New(18_00, money.USD)}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without a pickup branch the class booking is charged in the class
+	// rate's dollars; the cheapest car it gets is at the euro branch.
+	res, err := rs.CreateReservation(ReservationRequest{CustomerID: customer.ID, VehicleClass: "economy", StartDate: "2025-03-10", EndDate: "2025-03-12"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res, err = rs.AssignCar(res.ID); err != nil {
+		t.Fatal(err)
+	}
+	if res.CarID != 3 || res.PickupBranchID != paris.ID {
+		t.Fatalf("assigned car %d at branch %d, want car 3 at %d", res.CarID, res.PickupBranchID, paris.ID)
+	}
+
+	steps := []struct {
+		name string
+		run  func() error
+	}{
+		{"modify", func() error { return rs.ModifyReservation(res.ID, "2025-03-10", "2025-03-13") }},
+		{"set extras", func() error {
+			_, err := rs.SetReservationExtras(res.ID, []ExtraRequest{{Code: "cdw", Quantity: 1}})
+			return err
+		}},
+		{"pay", func() error {
+			_, err := rs.ProcessPayment(res.ID, money.Money{}, "card")
+			return err
+		}},
+	}
+	for _, step := range steps {
+		if err := step.run(); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		saved, err := rs.GetReservation(res.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for name, amount := range map[string]money.Money{"total": saved.TotalPrice, "paid": saved.AmountPaid, "due": saved.AmountDue} {
+			if amount.Currency != money.USD {
+				t.Errorf("after %s: %s is in %s, want USD", step.name, name, amount.Currency)
+			}
+		}
+	}
+}
+
+func TestSettleBalance(t *testing.T) {
+	usd := func(amount int64) money.Money { return money.New(amount, money.USD) }
+	tests := []struct {
+		name       string
+		res        models.Reservation
+		wantDue    money.Money
+		wantRefund money.Money
+		wantErr    error
+	}{
+		{"nothing paid yet", models.Reservation{TotalPrice: usd(100_00)}, usd(100_00), usd(0), nil},
+		{"part paid", models.Reservation{TotalPrice: usd(100_00), AmountPaid: usd(40_00)}, usd(60_00), usd(0), nil},
+		{"paid in full", models.Reservation{TotalPrice: usd(100_00), AmountPaid: usd(100_00)}, usd(0), usd(0), nil},
+		{"cancelled after paying", models.Reservation{Status: models.ReservationCancelled, TotalPrice: usd(100_00), CancellationFee: usd(20_00), AmountPaid: usd(100_00)}, usd(0), usd(80_00), nil},
+		{"paid in another currency", models.Reservation{TotalPrice: usd(100_00), AmountPaid: money.New(40_00, money.EUR)}, money.Money{}, money.Money{}, ErrCurrencyMismatch},
+		{"fee in another currency", models.Reservation{Status: models.ReservationCancelled, TotalPrice: usd(100_00), CancellationFee: money.New(20_00, money.EUR)}, money.Money{}, money.Money{}, ErrCurrencyMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := tt.res
+			err := settleBalance(&res)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if res.AmountPaid != tt.res.AmountPaid {
+					t.Errorf("amount paid changed to %v", res.AmountPaid)
+				}
+				return
+			}
+			if res.AmountDue != tt.wantDue || res.RefundDue != tt.wantRefund {
+				t.Errorf("due %v, refund %v; want %v, %v", res.AmountDue, res.RefundDue, tt.wantDue, tt.wantRefund)
+			}
+			if res.Paid != tt.wantDue.IsZero() {
+				t.Errorf("paid = %v", res.Paid)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	models "car-rental-system/rental_system_models"
+	"car-rental-system/repository"
+	"errors"
+	"strings"
+)
+
+// normalizeLicense makes "d 123-456" and "D123-456" the same license so
+// duplicates are caught regardless of how they were typed.
+func normalizeLicense(license string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(license), ""))
+}
+
+func findCustomer(repo repository.Repository, customerID int) (*models.Customer, error) {
+	customer, err := repo.Customer(customerID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrCustomerNotFound
+	}
+	return customer, err
+}
+
+// checkLicenseFree fails when another customer already holds license.
+func checkLicenseFree(repo repository.Repository, license string, customerID int) error {
+	existing, err := repo.CustomerByLicense(license)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID != customerID {
+		return ErrDuplicateLicense
+	}
+	return nil
+}
+
+func validateCustomer(customer *models.Customer) error {
+	customer.DriversLicense = normalizeLicense(customer.DriversLicense)
+	customer.Email = strings.TrimSpace(customer.Email)
+	customer.LicenseRegion = strings.ToUpper(strings.TrimSpace(customer.LicenseRegion))
+	if strings.TrimSpace(customer.Name) == "" || customer.DriversLicense == "" {
+		return ErrInvalidCustomer
+	}
+	if customer.Email != "" && !strings.Contains(customer.Email, "@") {
+		return ErrInvalidCustomer
+	}
+	return nil
+}
+
+// RegisterCustomer adds a customer and gives them an ID. Each driver's
+// license can only be registered once.
+func (rs *RentalSystem) RegisterCustomer(customer models.Customer) (*models.Customer, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	customer.ID = 0
+	if err := validateCustomer(&customer); err != nil {
+		return nil, err
+	}
+	if err := checkLicenseFree(rs.repo, customer.DriversLicense, 0); err != nil {
+		return nil, err
+	}
+	if err := rs.repo.SaveCustomer(&customer); err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+// UpdateCustomer replaces the details of the customer with customer.ID.
+// Existing reservations keep pointing at the same customer.
+func (rs *RentalSystem) UpdateCustomer(customer models.Customer) (*models.Customer, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if _, err := findCustomer(rs.repo, customer.ID); err != nil {
+		return nil, err
+	}
+	if err := validateCustomer(&customer); err != nil {
+		return nil, err
+	}
+	if err := checkLicenseFree(rs.repo, customer.DriversLicense, customer.ID); err != nil {
+		return nil, err
+	}
+	if err := rs.repo.SaveCustomer(&customer); err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+func (rs *RentalSystem) GetCustomer(customerID int) (*models.Customer, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return findCustomer(rs.repo, customerID)
+}
+
+func (rs *RentalSystem) FindCustomerByLicense(license string) (*models.Customer, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	customer, err := rs.repo.CustomerByLicense(normalizeLicense(license))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrCustomerNotFound
+	}
+	return customer, err
+}
+
+// CustomerHistory returns every reservation the customer has made,
+// including cancelled ones, oldest first.
+func (rs *RentalSystem) CustomerHistory(customerID int) ([]models.Reservation, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if _, err := findCustomer(rs.repo, customerID); err != nil {
+		return nil, err
+	}
+	return rs.repo.ReservationsForCustomer(customerID)
+}
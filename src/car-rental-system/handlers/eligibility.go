@@ -0,0 +1,57 @@
+package services
+
+import (
+	"car-rental-system/eligibility"
+	models "car-rental-system/rental_system_models"
+	"car-rental-system/repository"
+	"errors"
+	"time"
+)
+
+// checkEligibility rejects drivers on the blocklist first and then runs the
+// configured checks. Rejections are the typed errors of the eligibility
+// package.
+func (rs *RentalSystem) checkEligibility(customer *models.Customer, car *models.Car, start, end time.Time) error {
+	blocked, err := rs.repo.BlockedLicense(customer.DriversLicense)
+	if err == nil {
+		return &eligibility.BlockedError{License: blocked.License, Reason: blocked.Reason}
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+
+	return rs.eligibility.Check(eligibility.Request{Car: *car, Customer: *customer, Start: start, End: end})
+}
+
+// BlockLicense bans a driver's license from new bookings. Reservations
+// already made with it are left alone.
+func (rs *RentalSystem) BlockLicense(license, reason string) (*models.BlockedLicense, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	entry := &models.BlockedLicense{License: normalizeLicense(license), Reason: reason, BlockedAt: rs.now()}
+	if entry.License == "" {
+		return nil, ErrInvalidCustomer
+	}
+	if err := rs.repo.SaveBlockedLicense(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (rs *RentalSystem) UnblockLicense(license string) error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	err := rs.repo.DeleteBlockedLicense(normalizeLicense(license))
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrLicenseNotBlocked
+	}
+	return err
+}
+
+func (rs *RentalSystem) BlockedLicenses() ([]models.BlockedLicense, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return rs.repo.BlockedLicenses()
+}
@@ -0,0 +1,72 @@
+package services
+
+import "errors"
+
+var (
+	ErrCarNotFound          = errors.New("car not found")
+	ErrCarNotAvailable      = errors.New("car not available")
+	ErrCarAlreadyExists     = errors.New("car already exists")
+	ErrCarRetired           = errors.New("car is retired")
+	ErrInvalidCarStatus     = errors.New("invalid car status")
+	ErrMaintenanceNotFound  = errors.New("maintenance window not found")
+	ErrVehicleClassNotFound = errors.New("vehicle class not found")
+	ErrVehicleClassExists   = errors.New("vehicle class already exists")
+	ErrInvalidVehicleClass  = errors.New("vehicle class needs a code, a name and known attributes")
+	ErrClassSoldOut         = errors.New("no cars left in this vehicle class or above")
+	ErrCarAlreadyAssigned   = errors.New("reservation already has a car")
+	ErrCarOrClassRequired   = errors.New("a car or a vehicle class is required")
+	ErrInvalidSearch        = errors.New("invalid search criteria")
+	ErrInvalidCursor        = errors.New("search cursor is invalid or does not match the sort order")
+	ErrBranchNotFound       = errors.New("branch not found")
+	ErrInvalidBranch        = errors.New("branch needs a name and valid opening hours")
+	ErrBranchClosed         = errors.New("branch is closed at that time")
+	ErrCarNotAtBranch       = errors.New("car will not be at the pickup branch")
+	ErrCustomerNotFound     = errors.New("customer not found")
+	ErrDuplicateLicense     = errors.New("a customer with this driver's license already exists")
+	ErrInvalidCustomer      = errors.New("customer needs a name, a driver's license and a valid email")
+	ErrLicenseNotBlocked    = errors.New("driver's license is not blocked")
+	ErrReservationNotFound  = errors.New("reservation not found")
+	ErrReservationCancelled = errors.New("reservation is cancelled")
+	ErrAlreadyPickedUp      = errors.New("reservation has already been picked up")
+	ErrNotPickedUp          = errors.New("reservation has not been picked up")
+	ErrReservationCompleted = errors.New("reservation is already completed")
+	ErrPickupTooEarly       = errors.New("car cannot be picked up before the rental starts")
+	ErrInvalidHandover      = errors.New("odometer and energy level must be valid readings")
+	ErrAlreadyPaid          = errors.New("reservation already paid")
+	ErrPaymentNotFound      = errors.New("payment not found")
+	ErrInvoiceNotFound      = errors.New("invoice not found")
+	ErrWaitlistNotFound     = errors.New("waitlist entry not found")
+	ErrAlreadyWaitlisted    = errors.New("customer is already on the waitlist for these dates")
+	ErrWaitlistEntryClosed  = errors.New("waitlist entry is no longer open")
+	ErrNoWaitlistOffer      = errors.New("waitlist entry has no open offer")
+	ErrWaitlistOfferExpired = errors.New("waitlist offer has expired")
+	ErrHoldNotFound         = errors.New("hold not found")
+	ErrHoldNeedsCar         = errors.New("a hold needs a car")
+	ErrHoldNotActive        = errors.New("hold is no longer active")
+	ErrHoldExpired          = errors.New("hold has expired")
+	ErrExtraNotFound        = errors.New("extra not found")
+	ErrExtraExists          = errors.New("extra already exists")
+	ErrInvalidExtra         = errors.New("extra needs a code, a name, a known kind and pricing, and no negative amounts")
+	ErrExtraNotStocked      = errors.New("extra is not stocked per branch")
+	ErrExtraQuantity        = errors.New("extra quantity must be between one and the limit per reservation")
+	ErrExtraSoldOut         = errors.New("extra is sold out at the pickup branch for these dates")
+	ErrInvalidPoints        = errors.New("points to redeem must not be negative")
+	ErrInvalidLoyaltyPolicy = errors.New("loyalty policy amounts must be in the base currency and not negative")
+	ErrInsufficientPoints   = errors.New("not enough loyalty points")
+	ErrInvalidUsagePolicy   = errors.New("usage policy amounts must be in the base currency and not negative")
+	ErrPromoNotFound        = errors.New("promo code not found")
+	ErrPromoExists          = errors.New("promo code already exists")
+	ErrInvalidPromo         = errors.New("promo code needs a code, a known kind, a positive value within limits and a valid window")
+	ErrPromoNotValid        = errors.New("promo code is not valid at this time")
+	ErrPromoNotApplicable   = errors.New("promo code does not apply to this rental")
+	ErrPromoUsedUp          = errors.New("promo code has reached its usage limit")
+	ErrPromoNotStackable    = errors.New("promo code cannot be combined with loyalty points")
+	ErrInvalidExchangeRate  = errors.New("exchange rate needs two different currencies and a positive rate")
+	ErrCurrencyMismatch     = errors.New("amount is not in the reservation's currency")
+	ErrPaymentDeclined      = errors.New("payment declined")
+	ErrInvalidAmount        = errors.New("invalid payment amount")
+	ErrInvalidPaymentState  = errors.New("payment cannot be changed in its current state")
+	ErrInvalidDate          = errors.New("invalid date")
+	ErrEndBeforeStart       = errors.New("end date must be after start date")
+	ErrDateInPast           = errors.New("start date is in the past")
+)
@@ -0,0 +1,48 @@
+package services
+
+import "car-rental-system/events"
+
+// publish queues e until the rental system's lock is released, so
+// synchronous subscribers can call back into the system. Events are only
+// raised once a change has been saved.
+func (rs *RentalSystem) publish(e events.Event) {
+	if rs.events != nil {
+		rs.pending = append(rs.pending, e)
+	}
+}
+
+// unlock releases mu and then publishes the events raised while it was
+// held. Methods that raise events defer it instead of mu.Unlock.
+//
+// The events are moved to the outbox before mu is released, so the outbox
+// holds them in the order the changes were made, and only one goroutine
+// publishes from it at a time. When another goroutine, or a subscriber
+// calling back into the system, is already draining the outbox, unlock
+// leaves its events there to be published in turn and returns at once:
+// waiting for them could deadlock a subscriber that called back in, as
+// they can only be published once it returns.
+func (rs *RentalSystem) unlock() {
+	rs.outboxMu.Lock()
+	rs.outbox = append(rs.outbox, rs.pending...)
+	rs.pending = nil
+	drain := !rs.draining && len(rs.outbox) > 0
+	rs.draining = rs.draining || drain
+	rs.outboxMu.Unlock()
+	rs.mu.Unlock()
+	if !drain {
+		return
+	}
+
+	for {
+		rs.outboxMu.Lock()
+		if len(rs.outbox) == 0 {
+			rs.draining = false
+			rs.outboxMu.Unlock()
+			return
+		}
+		e := rs.outbox[0]
+		rs.outbox = rs.outbox[1:]
+		rs.outboxMu.Unlock()
+		rs.events.Publish(e)
+	}
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"car-rental-system/events"
+	models "car-rental-system/rental_system_models"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEventsPublishedInCommitOrder(t *testing.T) {
+	var tick time.Duration
+	start := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+	// The clock is only read under the system's lock, so the times give
+	// the order the changes were made in.
+	clock := func() time.Time {
+		tick++
+		return start.Add(tick)
+	}
+
+	bus := events.NewBus()
+	rs := NewRentalSystem(WithClock(clock), WithEventBus(bus))
+
+	var mu sync.Mutex
+	var seen []time.Time
+	bus.Subscribe(events.SubscriberFunc(func(e events.Event) {
+		// A slow subscriber gives later changes the chance to overtake.
+		time.Sleep(e.OccurredAt().Sub(start) % 3 * 100 * time.Microsecond)
+		mu.Lock()
+		seen = append(seen, e.OccurredAt())
+		mu.Unlock()
+		// Subscribers may call back into the system.
+		if _, err := rs.ListCars(); err != nil {
+			t.Error(err)
+		}
+	}))
+
+	const cars = 50
+	var wg sync.WaitGroup
+	for id := 1; id <= cars; id++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := rs.AddCar(models.Car{ID: id, Make: "Toyota", Model: "Corolla", LicensePlate: "ABC123"}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(seen) != cars {
+		t.Fatalf("published %d events, want %d", len(seen), cars)
+	}
+	for i := 1; i < len(seen); i++ {
+		if !seen[i-1].Before(seen[i]) {
+			t.Fatalf("event %d published after event %d", i, i+1)
+		}
+	}
+}
+
+func TestEventsDeliveredBeforeCallReturns(t *testing.T) {
+	bus := events.NewBus()
+	rs, customer := newTestSystem(t, WithEventBus(bus))
+
+	var created []int
+	bus.Subscribe(events.SubscriberFunc(func(e events.Event) {
+		if e, ok := e.(events.ReservationCreated); ok {
+			created = append(created, e.Reservation.ID)
+		}
+	}))
+
+	res, err := rs.CreateReservation(ReservationRequest{CustomerID: customer.ID, CarID: 1, StartDate: "2025-03-10", EndDate: "2025-03-12"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 1 || created[0] != res.ID {
+		t.Errorf("subscriber saw %v by the time CreateReservation returned, want [%d]", created, res.ID)
+	}
+}
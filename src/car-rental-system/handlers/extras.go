@@ -0,0 +1,390 @@
+package services
+
+import (
+	"car-rental-system/events"
+	models "car-rental-system/rental_system_models"
+	"car-rental-system/repository"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ExtraRequest asks for Quantity units of the extra with Code.
+type ExtraRequest struct {
+	Code     string
+	Quantity int
+}
+
+// ExtraAvailability is how many units of a stocked extra a branch has free
+// over a period.
+type ExtraAvailability struct {
+	Extra     models.Extra `json:"extra"`
+	Available int          `json:"available"`
+}
+
+func findExtra(repo repository.Repository, code string) (*models.Extra, error) {
+	extra, err := repo.Extra(code)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrExtraNotFound
+	}
+	return extra, err
+}
+
+func normalizeExtraCode(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
+
+func validateExtra(extra *models.Extra) error {
+	extra.Code = normalizeExtraCode(extra.Code)
+	if extra.Code == "" || strings.TrimSpace(extra.Name) == "" || extra.Price.IsNegative() || extra.MaxPerReservation < 0 {
+		return ErrInvalidExtra
+	}
+	switch extra.Kind {
+	case models.ExtraEquipment, models.ExtraInsurance, models.ExtraDriver:
+	default:
+		return ErrInvalidExtra
+	}
+	switch extra.Pricing {
+	case models.ExtraPerDay, models.ExtraPerRental:
+	default:
+		return ErrInvalidExtra
+	}
+	if !extra.Stocked && len(extra.Stock) > 0 {
+		return ErrExtraNotStocked
+	}
+	seen := make(map[int]bool, len(extra.Stock))
+	for _, stock := range extra.Stock {
+		if stock.Quantity < 0 || seen[stock.BranchID] {
+			return ErrInvalidExtra
+		}
+		seen[stock.BranchID] = true
+	}
+	return nil
+}
+
+// AddExtra adds an extra to the catalogue.
+func (rs *RentalSystem) AddExtra(extra models.Extra) (*models.Extra, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if err := validateExtra(&extra); err != nil {
+		return nil, err
+	}
+	if err := rs.normalizeAmount(&extra.Price, ""); err != nil {
+		return nil, err
+	}
+	if _, err := rs.repo.Extra(extra.Code); err == nil {
+		return nil, ErrExtraExists
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	for _, stock := range extra.Stock {
+		if stock.BranchID == 0 {
+			continue
+		}
+		if _, err := findBranch(rs.repo, stock.BranchID); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(extra.Stock, func(i, j int) bool { return extra.Stock[i].BranchID < extra.Stock[j].BranchID })
+	if err := rs.repo.SaveExtra(&extra); err != nil {
+		return nil, err
+	}
+	return &extra, nil
+}
+
+func (rs *RentalSystem) GetExtra(code string) (*models.Extra, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return findExtra(rs.repo, normalizeExtraCode(code))
+}
+
+// ListExtras returns the catalogue ordered by code.
+func (rs *RentalSystem) ListExtras() ([]models.Extra, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return rs.repo.Extras()
+}
+
+// SetExtraStock sets how many units of a stocked extra the branch holds.
+// Lowering it below what is already booked does not touch existing
+// reservations; it only stops new ones.
+func (rs *RentalSystem) SetExtraStock(code string, branchID, quantity int) (*models.Extra, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	extra, err := findExtra(rs.repo, normalizeExtraCode(code))
+	if err != nil {
+		return nil, err
+	}
+	if !extra.Stocked {
+		return nil, ErrExtraNotStocked
+	}
+	if quantity < 0 {
+		return nil, ErrInvalidExtra
+	}
+	if branchID != 0 {
+		if _, err := findBranch(rs.repo, branchID); err != nil {
+			return nil, err
+		}
+	}
+
+	stock := make([]models.BranchStock, 0, len(extra.Stock)+1)
+	for _, s := range extra.Stock {
+		if s.BranchID != branchID {
+			stock = append(stock, s)
+		}
+	}
+	stock = append(stock, models.BranchStock{BranchID: branchID, Quantity: quantity})
+	sort.Slice(stock, func(i, j int) bool { return stock[i].BranchID < stock[j].BranchID })
+	extra.Stock = stock
+	if err := rs.repo.SaveExtra(extra); err != nil {
+		return nil, err
+	}
+	return extra, nil
+}
+
+// AvailableExtras reports how many units of each stocked extra are free at
+// the branch over [startDate, endDate). Extras that are not stocked are
+// never sold out and are left out.
+func (rs *RentalSystem) AvailableExtras(startDate, endDate string, branchID int) ([]ExtraAvailability, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	start, end, err := rs.parseRentalPeriod(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	extras, err := rs.repo.Extras()
+	if err != nil {
+		return nil, err
+	}
+	var result []ExtraAvailability
+	for _, extra := range extras {
+		if !extra.Stocked {
+			continue
+		}
+		booked, err := rs.extrasBooked(extra.Code, branchID, start, end, 0)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, ExtraAvailability{Extra: extra, Available: max(extra.StockAt(branchID)-booked, 0)})
+	}
+	return result, nil
+}
+
+// extraUse is a number of units of an extra out over [start, end).
+type extraUse struct {
+	start, end time.Time
+	quantity   int
+}
+
+// peakUse returns the most units out at any one time during [start, end).
+// Units returned at the moment others go out can be handed out again.
+func peakUse(uses []extraUse, start, end time.Time) int {
+	type change struct {
+		at    time.Time
+		delta int
+	}
+	changes := make([]change, 0, 2*len(uses))
+	for _, use := range uses {
+		if !use.start.Before(end) || !start.Before(use.end) {
+			continue
+		}
+		from, to := use.start, use.end
+		if from.Before(start) {
+			from = start
+		}
+		if end.Before(to) {
+			to = end
+		}
+		changes = append(changes, change{from, use.quantity}, change{to, -use.quantity})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if !changes[i].at.Equal(changes[j].at) {
+			return changes[i].at.Before(changes[j].at)
+		}
+		return changes[i].delta < changes[j].delta
+	})
+	out, peak := 0, 0
+	for _, c := range changes {
+		out += c.delta
+		peak = max(peak, out)
+	}
+	return peak
+}
+
+// extrasBooked returns the most units of an extra that reservations other
+// than ignoreID, holds and waitlist offers have taken from the branch at
+// any one time during [start, end). Units go out with the car at pickup
+// and are counted against that branch until the rental ends, wherever the
+// car is dropped off.
+func (rs *RentalSystem) extrasBooked(code string, branchID int, start, end time.Time, ignoreID int) (int, error) {
+	reservations, err := rs.repo.Reservations()
+	if err != nil {
+		return 0, err
+	}
+	var uses []extraUse
+	for _, res := range reservations {
+		if res.ID == ignoreID || res.PickupBranchID != branchID {
+			continue
+		}
+		// Returned extras are back on the shelf, like returned cars.
+		if res.Status == models.ReservationCancelled || res.Status == models.ReservationCompleted {
+			continue
+		}
+		for _, extra := range res.Extras {
+			if extra.Code == code {
+				uses = append(uses, extraUse{start: res.StartDate, end: res.EndDate, quantity: extra.Quantity})
+			}
+		}
+	}
+	uses = append(uses, rs.calendar.extrasHeld(code, branchID)...)
+	return peakUse(uses, start, end), nil
+}
+
+// checkExtraStock makes sure the branch has enough of every stocked extra
+// free over [start, end), leaving out the reservation ignoreID.
+func (rs *RentalSystem) checkExtraStock(extras []models.ReservationExtra, branchID int, start, end time.Time, ignoreID int) error {
+	for _, requested := range extras {
+		extra, err := findExtra(rs.repo, requested.Code)
+		if err != nil {
+			return err
+		}
+		if !extra.Stocked {
+			continue
+		}
+		booked, err := rs.extrasBooked(extra.Code, branchID, start, end, ignoreID)
+		if err != nil {
+			return err
+		}
+		if booked+requested.Quantity > extra.StockAt(branchID) {
+			return ErrExtraSoldOut
+		}
+	}
+	return nil
+}
+
+// resolveExtras turns the requested extras into reservation lines priced
+// from the catalogue and checks they are in stock. Extras in current keep
+// the price they were booked at. Requests for the same extra are added
+// up.
+func (rs *RentalSystem) resolveExtras(requests []ExtraRequest, current []models.ReservationExtra, branchID int, start, end time.Time, ignoreID int) ([]models.ReservationExtra, error) {
+	extras, err := rs.lookupExtras(requests, current)
+	if err != nil {
+		return nil, err
+	}
+	if err := rs.checkExtraStock(extras, branchID, start, end, ignoreID); err != nil {
+		return nil, err
+	}
+	return extras, nil
+}
+
+// lookupExtras is resolveExtras without the stock check.
+func (rs *RentalSystem) lookupExtras(requests []ExtraRequest, current []models.ReservationExtra) ([]models.ReservationExtra, error) {
+	var extras []models.ReservationExtra
+	var limits []int
+	index := make(map[string]int, len(requests))
+	for _, req := range requests {
+		if req.Quantity < 1 {
+			return nil, ErrExtraQuantity
+		}
+		code := normalizeExtraCode(req.Code)
+		if i, seen := index[code]; seen {
+			extras[i].Quantity += req.Quantity
+			continue
+		}
+		extra, err := findExtra(rs.repo, code)
+		if err != nil {
+			return nil, err
+		}
+		line := models.ReservationExtra{Code: extra.Code, Name: extra.Name, Pricing: extra.Pricing, Price: extra.Price, Quantity: req.Quantity}
+		for _, booked := range current {
+			if booked.Code == code {
+				line.Name, line.Pricing, line.Price = booked.Name, booked.Pricing, booked.Price
+			}
+		}
+		index[code] = len(extras)
+		extras = append(extras, line)
+		limits = append(limits, max(extra.MaxPerReservation, 1))
+	}
+
+	for i, line := range extras {
+		if line.Quantity > limits[i] {
+			return nil, ErrExtraQuantity
+		}
+	}
+	return extras, nil
+}
+
+// extraRequests asks again for extras chosen earlier, e.g. with a hold.
+func extraRequests(extras []models.ReservationExtra) []ExtraRequest {
+	requests := make([]ExtraRequest, 0, len(extras))
+	for _, extra := range extras {
+		requests = append(requests, ExtraRequest{Code: extra.Code, Quantity: extra.Quantity})
+	}
+	return requests
+}
+
+// SetReservationExtras replaces the extras booked on a reservation and
+// reprices it. Extras it already had keep the price they were booked at.
+// An empty list removes them all.
+func (rs *RentalSystem) SetReservationExtras(reservationID int, requests []ExtraRequest) (*models.Reservation, error) {
+	rs.mu.Lock()
+	defer rs.unlock()
+
+	res, err := findReservation(rs.repo, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	switch res.Status {
+	case models.ReservationCancelled:
+		return nil, ErrReservationCancelled
+	case models.ReservationInProgress, models.ReservationCompleted:
+		return nil, ErrAlreadyPickedUp
+	}
+
+	extras, err := rs.resolveExtras(requests, res.Extras, res.PickupBranchID, res.StartDate, res.EndDate, res.ID)
+	if err != nil {
+		return nil, err
+	}
+	car, err := rs.pricedCar(res)
+	if err != nil {
+		return nil, err
+	}
+	customer, err := findCustomer(rs.repo, res.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := *res
+	res.Extras = extras
+	if err := rs.reprice(res, car, customer); err != nil {
+		return nil, err
+	}
+	err = rs.repo.Transaction(func(tx repository.Repository) error {
+		if err := tx.SaveReservation(res); err != nil {
+			return err
+		}
+		return rs.bill(tx, res, reasonExtrasChanged)
+	})
+	if err != nil {
+		return nil, err
+	}
+	rs.publish(events.ReservationModified{Reservation: *res, Previous: previous, At: rs.now()})
+	return res, nil
+}
+
+// pricedCar is the car a reservation is charged for: the class stand-in
+// for class bookings, which keep the class rate even after an upgrade.
+func (rs *RentalSystem) pricedCar(res *models.Reservation) (*models.Car, error) {
+	if !res.ClassOnly {
+		return findCar(rs.repo, res.CarID)
+	}
+	class, err := findVehicleClass(rs.repo, res.VehicleClass)
+	if err != nil {
+		return nil, err
+	}
+	return classCar(class), nil
+}
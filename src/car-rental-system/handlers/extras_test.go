@@ -0,0 +1,83 @@
+package services
+
+import (
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"errors"
+	"testing"
+)
+
+// newExtrasTestSystem adds a third car and a child seat stocked twice
+// without a branch to the test system.
+func newExtrasTestSystem(t *testing.T) (*RentalSystem, *models.Customer) {
+	t.Helper()
+	rs, customer := newTestSystem(t)
+	if err := rs.AddCar(models.Car{ID: 3, Make: "Mazda", Model: "3", Year: 2022, LicensePlate: "MZD333", RentalPricePerDay: money.New(55_00, money.USD), Class: "compact"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rs.AddExtra(models.Extra{
+		Code:              "child_seat",
+		Name:              "Child seat",
+		Kind:              models.ExtraEquipment,
+		Pricing:           models.ExtraPerDay,
+		Price:             money.New(8_00, money.USD),
+		MaxPerReservation: 2,
+		Stocked:           true,
+		Stock:             []models.BranchStock{{BranchID: 0, Quantity: 2}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return rs, customer
+}
+
+func TestExtraStockCountsUnitsOutAtOnce(t *testing.T) {
+	rs, customer := newExtrasTestSystem(t)
+	seat := []ExtraRequest{{Code: "child_seat", Quantity: 1}}
+	book := func(carID int, start, end string) error {
+		_, err := rs.CreateReservation(ReservationRequest{CustomerID: customer.ID, CarID: carID, StartDate: start, EndDate: end, Extras: seat})
+		return err
+	}
+	// One seat goes out twice on car 1, with the shelf full in between.
+	if err := book(1, "2025-03-10", "2025-03-12"); err != nil {
+		t.Fatal(err)
+	}
+	if err := book(1, "2025-03-14", "2025-03-16"); err != nil {
+		t.Fatal(err)
+	}
+
+	available := []struct {
+		start, end string
+		want       int
+	}{
+		{"2025-03-10", "2025-03-16", 1},
+		{"2025-03-10", "2025-03-12", 1},
+		{"2025-03-12", "2025-03-14", 2},
+		{"2025-03-11", "2025-03-15", 1},
+	}
+	for _, tt := range available {
+		extras, err := rs.AvailableExtras(tt.start, tt.end, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(extras) != 1 || extras[0].Available != tt.want {
+			t.Errorf("available %s to %s = %+v, want %d seats", tt.start, tt.end, extras, tt.want)
+		}
+	}
+
+	bookings := []struct {
+		name    string
+		carID   int
+		start   string
+		end     string
+		wantErr error
+	}{
+		{"across both bookings", 2, "2025-03-10", "2025-03-16", nil},
+		{"while two are out", 3, "2025-03-11", "2025-03-12", ErrExtraSoldOut},
+		{"between the bookings", 3, "2025-03-12", "2025-03-14", nil},
+	}
+	for _, tt := range bookings {
+		if err := book(tt.carID, tt.start, tt.end); !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
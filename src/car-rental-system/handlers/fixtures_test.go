@@ -0,0 +1,57 @@
+package services
+
+import (
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"car-rental-system/repository"
+	"testing"
+	"time"
+)
+
+// testNow is the clock of the systems built by newTestSystem.
+var testNow = time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+
+// newTestSystem returns an in-memory system with an economy and a compact
+// class, one car of each (ids 1 and 2) and one customer.
+func newTestSystem(t *testing.T, opts ...Option) (*RentalSystem, *models.Customer) {
+	t.Helper()
+	return newTestSystemWithRepository(t, repository.NewMemoryRepository(), opts...)
+}
+
+// newTestSystemWithRepository is newTestSystem backed by repo.
+func newTestSystemWithRepository(t *testing.T, repo repository.Repository, opts ...Option) (*RentalSystem, *models.Customer) {
+	t.Helper()
+	opts = append([]Option{WithClock(func() time.Time { return testNow })}, opts...)
+	rs, err := NewRentalSystemWithRepository(repo, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, class := range []models.VehicleClass{
+		{Code: "economy", Name: "Economy", Rank: 1, DailyRate: money.New(45_00, money.USD)},
+		{Code: "compact", Name: "Compact", Rank: 2, DailyRate: money.New(55_00, money.USD)},
+	} {
+		if _, err := rs.AddVehicleClass(class); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, car := range []models.Car{
+		{ID: 1, Make: "Toyota", Model: "Corolla", Year: 2020, LicensePlate: "ABC123", RentalPricePerDay: money.New(50_00, money.USD), Class: "economy", Odometer: 20_000},
+		{ID: 2, Make: "Honda", Model: "Civic", Year: 2021, LicensePlate: "XYZ789", RentalPricePerDay: money.New(60_00, money.USD), Class: "compact", Odometer: 10_000},
+	} {
+		if err := rs.AddCar(car); err != nil {
+			t.Fatal(err)
+		}
+	}
+	customer, err := rs.RegisterCustomer(models.Customer{
+		Name:           "Ann Lee",
+		Email:          "ann@example.com",
+		DateOfBirth:    time.Date(1990, time.January, 2, 0, 0, 0, 0, time.UTC),
+		DriversLicense: "D7654321",
+		LicenseRegion:  "US-CA",
+		LicenseExpiry:  time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rs, customer
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	models "car-rental-system/rental_system_models"
+	"car-rental-system/repository"
+	"errors"
+	"time"
+)
+
+// MaintenanceResult is a scheduled maintenance window together with the
+// bookings it clashes with. Those reservations are kept; staff are expected
+// to move them to another car or contact the customer.
+type MaintenanceResult struct {
+	Window               models.MaintenanceWindow `json:"window"`
+	AffectedReservations []models.Reservation     `json:"affectedReservations"`
+}
+
+// endOfTime closes open-ended date ranges.
+var endOfTime = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+func findMaintenanceWindow(repo repository.Repository, windowID int) (*models.MaintenanceWindow, error) {
+	window, err := repo.MaintenanceWindow(windowID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrMaintenanceNotFound
+	}
+	return window, err
+}
+
+// carAvailable reports whether car can be rented for [start, end), ignoring
+// the booking of reservation ignoreID.
+func (rs *RentalSystem) carAvailable(car *models.Car, start, end time.Time, ignoreID int) bool {
+	return car.Rentable() && rs.calendar.isFree(car.ID, start, end, ignoreID)
+}
+
+// carBookable reports whether car can be booked by itself for [start, end):
+// it must be available, and taking it must still leave a car for every
+// class-only reservation and waitlist offer waiting in its class or below.
+func (rs *RentalSystem) carBookable(car *models.Car, start, end time.Time, ignoreID int) (bool, error) {
+	if !rs.carAvailable(car, start, end, ignoreID) {
+		return false, nil
+	}
+	loads, err := rs.classLoads(0, 0, start, end, 0)
+	if err != nil {
+		return false, err
+	}
+	return loads.leaveRoomWithout(car), nil
+}
+
+// affectedReservations loads the reservations booked on the car during
+// [start, end).
+func (rs *RentalSystem) affectedReservations(carID int, start, end time.Time) ([]models.Reservation, error) {
+	var affected []models.Reservation
+	for _, id := range rs.calendar.reservationsDuring(carID, start, end) {
+		res, err := findReservation(rs.repo, id)
+		if err != nil {
+			return nil, err
+		}
+		affected = append(affected, *res)
+	}
+	return affected, nil
+}
+
+// SetCarStatus moves a car to another lifecycle state. Retired cars stay
+// retired. When the car stops being rentable, the reservations it still
+// has from today on are returned so they can be rebooked.
+func (rs *RentalSystem) SetCarStatus(carID int, status models.CarStatus) ([]models.Reservation, error) {
+	rs.mu.Lock()
+	defer rs.unlock()
+
+	if !status.Valid() {
+		return nil, ErrInvalidCarStatus
+	}
+	car, err := findCar(rs.repo, carID)
+	if err != nil {
+		return nil, err
+	}
+	if car.Status == models.CarRetired && status != models.CarRetired {
+		return nil, ErrCarRetired
+	}
+
+	car.Status = status
+	if err := rs.repo.SaveCar(car); err != nil {
+		return nil, err
+	}
+	if car.Rentable() {
+		rs.offerWaitlist()
+		return nil, nil
+	}
+
+	return rs.affectedReservations(carID, startOfDay(rs.now()), endOfTime)
+}
+
+// ScheduleMaintenance blocks the car for [startDate, endDate). The window
+// is created even when it overlaps existing bookings; those are reported
+// back instead.
+func (rs *RentalSystem) ScheduleMaintenance(carID int, startDate, endDate, reason string) (*MaintenanceResult, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	start, end, err := rs.parseRentalPeriod(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	car, err := findCar(rs.repo, carID)
+	if err != nil {
+		return nil, err
+	}
+	if car.Status == models.CarRetired {
+		return nil, ErrCarRetired
+	}
+
+	affected, err := rs.affectedReservations(carID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	window := models.MaintenanceWindow{CarID: carID, Start: start, End: end, Reason: reason, CreatedAt: rs.now()}
+	if err := rs.repo.SaveMaintenanceWindow(&window); err != nil {
+		return nil, err
+	}
+	rs.calendar.block(carID, window.ID, start, end)
+
+	return &MaintenanceResult{Window: window, AffectedReservations: affected}, nil
+}
+
+// CancelMaintenance removes a maintenance window, freeing the car again.
+func (rs *RentalSystem) CancelMaintenance(windowID int) error {
+	rs.mu.Lock()
+	defer rs.unlock()
+
+	window, err := findMaintenanceWindow(rs.repo, windowID)
+	if err != nil {
+		return err
+	}
+	if err := rs.repo.DeleteMaintenanceWindow(windowID); err != nil {
+		return err
+	}
+	rs.calendar.unblock(window.CarID, window.ID)
+	rs.offerWaitlist()
+	return nil
+}
+
+// MaintenanceSchedule lists the car's maintenance windows, earliest first.
+func (rs *RentalSystem) MaintenanceSchedule(carID int) ([]models.MaintenanceWindow, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if _, err := findCar(rs.repo, carID); err != nil {
+		return nil, err
+	}
+	return rs.repo.MaintenanceWindowsForCar(carID)
+}
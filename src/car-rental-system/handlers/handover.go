@@ -0,0 +1,307 @@
+package services
+
+import (
+	"car-rental-system/events"
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"car-rental-system/repository"
+	"fmt"
+	"time"
+)
+
+// UsagePolicy decides what is charged on return for using the car beyond
+// what was booked. Its amounts are in the base currency and are converted
+// into the reservation's.
+type UsagePolicy struct {
+	// LateGracePeriod is how long after EndDate a car may come back before
+	// it counts as late.
+	LateGracePeriod time.Duration
+	// LateFeePerHour is charged for every started hour past EndDate once
+	// the grace period is used up.
+	LateFeePerHour money.Money
+	// IncludedKmPerDay is the distance covered by each billed day. Zero
+	// means unlimited mileage.
+	IncludedKmPerDay int
+	// ExcessKmRate is charged for every kilometre beyond the allowance.
+	ExcessKmRate money.Money
+	// RefuelRatePerPercent is charged for every percentage point of fuel,
+	// or battery charge, missing compared with pickup.
+	RefuelRatePerPercent money.Money
+	// RelocationFee is charged when the car comes back to another branch
+	// than the booked drop-off, for taking it back there.
+	RelocationFee money.Money
+}
+
+// DefaultUsagePolicy charges the same amounts whatever the base currency,
+// rounded up to its smallest unit where it has no cents.
+func DefaultUsagePolicy(base money.Currency) UsagePolicy {
+	units := func(amount string) money.Money {
+		m, err := money.Parse(amount, base)
+		if err != nil {
+			return money.New(1, base)
+		}
+		return m
+	}
+	return UsagePolicy{
+		LateGracePeriod:      30 * time.Minute,
+		LateFeePerHour:       units("15"),
+		IncludedKmPerDay:     250,
+		ExcessKmRate:         units("0.25"),
+		RefuelRatePerPercent: units("1.50"),
+		RelocationFee:        units("75"),
+	}
+}
+
+// validate checks the policy's amounts are in base, giving those without
+// a currency base's, and that nothing is negative.
+func (p *UsagePolicy) validate(base money.Currency) error {
+	for _, amount := range []*money.Money{&p.LateFeePerHour, &p.ExcessKmRate, &p.RefuelRatePerPercent, &p.RelocationFee} {
+		if amount.Currency == "" {
+			amount.Currency = base
+		}
+		if amount.Currency != base || amount.IsNegative() {
+			return ErrInvalidUsagePolicy
+		}
+	}
+	if p.LateGracePeriod < 0 || p.IncludedKmPerDay < 0 {
+		return ErrInvalidUsagePolicy
+	}
+	return nil
+}
+
+// Charges returns the price lines owed for a rental returned as recorded
+// in res.CheckIn, in the reservation's currency. Electric cars are
+// recharged rather than refuelled. A rental booked without a drop-off
+// branch may end at any branch.
+func (p UsagePolicy) Charges(res *models.Reservation, electric bool, exchange money.Exchange) ([]models.PriceLine, error) {
+	var lines []models.PriceLine
+	var err error
+	add := func(code, description string, quantity int, unitPrice money.Money) {
+		if err != nil {
+			return
+		}
+		if unitPrice, err = exchange.Convert(unitPrice, res.TotalPrice.Currency); err != nil {
+			return
+		}
+		lines = append(lines, models.PriceLine{
+			Code:        code,
+			Description: description,
+			Quantity:    quantity,
+			UnitPrice:   unitPrice,
+			Amount:      unitPrice.Mul(quantity),
+		})
+	}
+
+	late := res.CheckIn.At.Sub(res.EndDate)
+	if late > p.LateGracePeriod && p.LateFeePerHour.IsPositive() {
+		hours := int((late + time.Hour - 1) / time.Hour)
+		add("late_return", "Late return (hours)", hours, p.LateFeePerHour)
+	}
+
+	if p.IncludedKmPerDay > 0 && p.ExcessKmRate.IsPositive() {
+		driven := res.CheckIn.Odometer - res.CheckOut.Odometer
+		if excess := driven - p.IncludedKmPerDay*res.RentalDays; excess > 0 {
+			add("excess_mileage", fmt.Sprintf("Excess mileage (km over %d)", p.IncludedKmPerDay*res.RentalDays), excess, p.ExcessKmRate)
+		}
+	}
+
+	if missing := res.CheckOut.EnergyLevel - res.CheckIn.EnergyLevel; missing > 0 && p.RefuelRatePerPercent.IsPositive() {
+		if electric {
+			add("recharging", "Recharging (% of battery)", missing, p.RefuelRatePerPercent)
+		} else {
+			add("refuelling", "Refuelling (% of tank)", missing, p.RefuelRatePerPercent)
+		}
+	}
+
+	if res.DropoffBranchID != 0 && res.CheckIn.BranchID != res.DropoffBranchID && p.RelocationFee.IsPositive() {
+		add("relocation", "Return to another branch than booked", 1, p.RelocationFee)
+	}
+	return lines, err
+}
+
+// HandoverReport is what counter staff record when a car is picked up or
+// returned.
+type HandoverReport struct {
+	// Odometer is in kilometres.
+	Odometer int
+	// EnergyLevel is how full the tank or battery is, in percent.
+	EnergyLevel int
+	DamageNotes string
+	// BranchID is where the car is returned; zero means the booked
+	// drop-off branch. It is ignored at pickup.
+	BranchID int
+}
+
+// ReturnResult is a completed rental with what its return added to the
+// bill.
+type ReturnResult struct {
+	Reservation models.Reservation `json:"reservation"`
+	Charges     []models.PriceLine `json:"charges"`
+	// AmountDue is what the customer still owes, charges included.
+	AmountDue money.Money `json:"amountDue"`
+}
+
+func validateReport(report HandoverReport, minOdometer int) error {
+	if report.Odometer < minOdometer || report.EnergyLevel < 0 || report.EnergyLevel > 100 {
+		return ErrInvalidHandover
+	}
+	return nil
+}
+
+// CheckOut hands the car over to the customer. Class-only reservations are
+// given their car first, as AssignCar would. The car cannot be collected
+// before the day the rental starts.
+func (rs *RentalSystem) CheckOut(reservationID int, report HandoverReport) (*models.Reservation, error) {
+	rs.mu.Lock()
+	defer rs.unlock()
+
+	res, err := findReservation(rs.repo, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	switch res.Status {
+	case models.ReservationCancelled:
+		return nil, ErrReservationCancelled
+	case models.ReservationInProgress, models.ReservationCompleted:
+		return nil, ErrAlreadyPickedUp
+	}
+	now := rs.now()
+	if startOfDay(now).Before(startOfDay(res.StartDate)) {
+		return nil, ErrPickupTooEarly
+	}
+	// The car a class-only reservation gets is only saved together with
+	// the check-out, once the report has been found valid for it.
+	var assignment *carAssignment
+	var car *models.Car
+	if res.CarID == 0 {
+		if assignment, err = rs.chooseCar(res); err != nil {
+			return nil, err
+		}
+		car = &assignment.car
+	} else if car, err = findCar(rs.repo, res.CarID); err != nil {
+		return nil, err
+	}
+	if err := validateReport(report, car.Odometer); err != nil {
+		return nil, err
+	}
+
+	previous := *res
+	if assignment != nil {
+		assignment.apply(res)
+	}
+	assigned := *res
+	res.Status = models.ReservationInProgress
+	res.CheckOut = &models.Handover{
+		At:          now,
+		BranchID:    res.PickupBranchID,
+		Odometer:    report.Odometer,
+		EnergyLevel: report.EnergyLevel,
+		DamageNotes: report.DamageNotes,
+	}
+	if err := rs.repo.SaveReservation(res); err != nil {
+		return nil, err
+	}
+	if assignment != nil {
+		rs.calendar.book(res)
+		rs.publish(events.ReservationModified{Reservation: assigned, Previous: previous, At: now})
+	}
+	rs.publish(events.ReservationPickedUp{Reservation: *res, At: now})
+	return res, nil
+}
+
+// CheckIn takes the car back, charges whatever the usage policy says is
+// owed for late return, mileage, fuel and a return to another branch than
+// booked, and completes the reservation.
+// The car is free again from now on, even if it came back early.
+func (rs *RentalSystem) CheckIn(reservationID int, report HandoverReport) (*ReturnResult, error) {
+	rs.mu.Lock()
+	defer rs.unlock()
+
+	res, err := findReservation(rs.repo, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	switch res.Status {
+	case models.ReservationCancelled:
+		return nil, ErrReservationCancelled
+	case models.ReservationCompleted:
+		return nil, ErrReservationCompleted
+	case models.ReservationActive:
+		return nil, ErrNotPickedUp
+	}
+	if err := validateReport(report, res.CheckOut.Odometer); err != nil {
+		return nil, err
+	}
+	branchID := report.BranchID
+	if branchID == 0 {
+		branchID = res.DropoffBranchID
+	} else if _, err := findBranch(rs.repo, branchID); err != nil {
+		return nil, err
+	}
+	car, err := findCar(rs.repo, res.CarID)
+	if err != nil {
+		return nil, err
+	}
+	electric := false
+	if class, err := rs.repo.VehicleClass(car.Class); err == nil {
+		electric = class.FuelType == models.FuelElectric
+	}
+
+	now := rs.now()
+	res.CheckIn = &models.Handover{
+		At:          now,
+		BranchID:    branchID,
+		Odometer:    report.Odometer,
+		EnergyLevel: report.EnergyLevel,
+		DamageNotes: report.DamageNotes,
+	}
+	exchange, err := rs.exchange(rs.repo, res.RateDate)
+	if err != nil {
+		return nil, err
+	}
+	charges, err := rs.usagePolicy.Charges(res, electric, exchange)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range charges {
+		res.PriceBreakdown = append(res.PriceBreakdown, line)
+		if res.TotalPrice, err = res.TotalPrice.CheckedAdd(line.Amount); err != nil {
+			return nil, err
+		}
+	}
+	res.Status = models.ReservationCompleted
+	if err := settleBalance(res); err != nil {
+		return nil, err
+	}
+
+	car.Odometer = report.Odometer
+	if branchID != 0 {
+		car.BranchID = branchID
+	}
+	var earned *events.LoyaltyPointsEarned
+	err = rs.repo.Transaction(func(tx repository.Repository) error {
+		if err := tx.SaveReservation(res); err != nil {
+			return err
+		}
+		if err := tx.SaveCar(car); err != nil {
+			return err
+		}
+		if err := rs.bill(tx, res, reasonVehicleReturned); err != nil {
+			return err
+		}
+		// Rentals paid in full before the return earn their points now.
+		earned, err = rs.awardPoints(tx, res)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rs.calendar.release(res.CarID, res.ID)
+	rs.publish(events.ReservationCompleted{Reservation: *res, Charges: charges, At: now})
+	if earned != nil {
+		rs.publish(*earned)
+	}
+	rs.offerWaitlist()
+	return &ReturnResult{Reservation: *res, Charges: charges, AmountDue: res.AmountDue}, nil
+}
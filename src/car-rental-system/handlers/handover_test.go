@@ -0,0 +1,161 @@
+package services
+
+import (
+	"car-rental-system/events"
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"car-rental-system/repository"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCheckOutClassReservation(t *testing.T) {
+	tests := []struct {
+		name     string
+		odometer int
+		wantErr  error
+		wantCar  int
+		want     []string
+	}{
+		{"report below the assigned car's odometer", 15_000, ErrInvalidHandover, 0, nil},
+		{"valid report", 20_000, nil, 1, []string{events.NameReservationModified, events.NameReservationPickedUp}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := events.NewBus()
+			rs, customer := newTestSystem(t, WithEventBus(bus))
+			res, err := rs.CreateReservation(ReservationRequest{
+				CustomerID:   customer.ID,
+				VehicleClass: "economy",
+				StartDate:    "2025-03-01",
+				EndDate:      "2025-03-03",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var published []string
+			bus.Subscribe(events.SubscriberFunc(func(e events.Event) { published = append(published, e.Name()) }))
+
+			_, err = rs.CheckOut(res.ID, HandoverReport{Odometer: tt.odometer, EnergyLevel: 100})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CheckOut: got %v, want %v", err, tt.wantErr)
+			}
+			saved, err := rs.GetReservation(res.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if saved.CarID != tt.wantCar {
+				t.Errorf("saved car = %d, want %d", saved.CarID, tt.wantCar)
+			}
+			if !slices.Equal(published, tt.want) {
+				t.Errorf("published %v, want %v", published, tt.want)
+			}
+			if free, err := rs.IsCarAvailable(1, "2025-03-01", "2025-03-03"); err != nil || free != (tt.wantCar == 0) {
+				t.Errorf("car 1 available = %v, %v", free, err)
+			}
+		})
+	}
+}
+
+func TestUsagePolicyValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  UsagePolicy
+		wantErr error
+	}{
+		{"default", DefaultUsagePolicy(money.EUR), nil},
+		{"amounts without a currency", UsagePolicy{LateFeePerHour: money.New(10_00, ""), RefuelRatePerPercent: money.New(1_00, "")}, nil},
+		{"late fee in another currency", UsagePolicy{LateFeePerHour: money.New(10_00, money.USD)}, ErrInvalidUsagePolicy},
+		{"default in another currency", DefaultUsagePolicy(money.USD), ErrInvalidUsagePolicy},
+		{"negative mileage rate", UsagePolicy{ExcessKmRate: money.New(-1, money.EUR)}, ErrInvalidUsagePolicy},
+		{"negative allowance", UsagePolicy{IncludedKmPerDay: -1}, ErrInvalidUsagePolicy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := tt.policy
+			if err := policy.validate(money.EUR); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && policy.LateFeePerHour.Currency != money.EUR {
+				t.Errorf("late fee in %s, want EUR", policy.LateFeePerHour.Currency)
+			}
+		})
+	}
+}
+
+func TestCheckInChargesInBaseCurrency(t *testing.T) {
+	euro := CurrencyPolicy{Base: money.EUR, RateDate: RateAtBooking, Rounding: money.HalfEven}
+	if _, err := NewRentalSystemWithRepository(repository.NewMemoryRepository(), WithCurrencyPolicy(euro), WithUsagePolicy(DefaultUsagePolicy(money.USD))); !errors.Is(err, ErrInvalidUsagePolicy) {
+		t.Errorf("dollar policy with a euro base: got %v, want %v", err, ErrInvalidUsagePolicy)
+	}
+
+	// No exchange rates at all: a euro rental is charged in euros.
+	rs, customer := newTestSystem(t, WithCurrencyPolicy(euro))
+	if err := rs.AddCar(models.Car{ID: 3, Make: "Renault", Model: "Clio", Year: 2022, LicensePlate: "PA123", RentalPricePerDay: money.New(30_00, money.EUR), Odometer: 5_000}); err != nil {
+		t.Fatal(err)
+	}
+	res, err := rs.CreateReservation(ReservationRequest{CustomerID: customer.ID, CarID: 3, StartDate: "2025-03-01", EndDate: "2025-03-03"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rs.CheckOut(res.ID, HandoverReport{Odometer: 5_000, EnergyLevel: 100}); err != nil {
+		t.Fatal(err)
+	}
+	result, err := rs.CheckIn(res.ID, HandoverReport{Odometer: 5_100, EnergyLevel: 80})
+	if err != nil {
+		t.Fatalf("CheckIn: %v", err)
+	}
+	want := money.New(30_00, money.EUR)
+	if len(result.Charges) != 1 || result.Charges[0].Code != "refuelling" || result.Charges[0].Amount != want {
+		t.Errorf("charges = %+v, want refuelling of %v", result.Charges, want)
+	}
+}
+
+func TestCheckInAtAnotherBranch(t *testing.T) {
+	tests := []struct {
+		name   string
+		branch string
+		want   []string
+	}{
+		{"booked drop-off", "Downtown", nil},
+		{"another branch", "Airport", []string{"relocation"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs, customer := newTestSystem(t)
+			branches := map[string]int{}
+			for _, name := range []string{"Downtown", "Airport"} {
+				branch, err := rs.AddBranch(models.Branch{Name: name})
+				if err != nil {
+					t.Fatal(err)
+				}
+				branches[name] = branch.ID
+			}
+			if _, err := rs.RelocateCar(1, branches["Downtown"]); err != nil {
+				t.Fatal(err)
+			}
+			res, err := rs.CreateReservation(ReservationRequest{CustomerID: customer.ID, CarID: 1, StartDate: "2025-03-01", EndDate: "2025-03-03"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := rs.CheckOut(res.ID, HandoverReport{Odometer: 20_000, EnergyLevel: 100}); err != nil {
+				t.Fatal(err)
+			}
+			result, err := rs.CheckIn(res.ID, HandoverReport{Odometer: 20_100, EnergyLevel: 100, BranchID: branches[tt.branch]})
+			if err != nil {
+				t.Fatalf("CheckIn: %v", err)
+			}
+			var codes []string
+			for _, line := range result.Charges {
+				codes = append(codes, line.Code)
+			}
+			if !slices.Equal(codes, tt.want) {
+				t.Errorf("charges = %+v, want %v", result.Charges, tt.want)
+			}
+			if len(codes) > 0 && result.Charges[0].Amount != money.New(75_00, money.USD) {
+				t.Errorf("relocation fee = %v, want 75.00 USD", result.Charges[0].Amount)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"car-rental-system/events"
+	models "car-rental-system/rental_system_models"
+	"car-rental-system/repository"
+	"errors"
+	"time"
+)
+
+// defaultHoldDuration is how long a hold lasts unless WithHoldDuration
+// says otherwise.
+const defaultHoldDuration = 15 * time.Minute
+
+func findHold(repo repository.Repository, holdID int) (*models.Hold, error) {
+	hold, err := repo.Hold(holdID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrHoldNotFound
+	}
+	return hold, err
+}
+
+// PlaceHold is the first step of a two-phase booking: it runs the checks
+// CreateReservation would and then keeps the car free for the customer
+// until the hold expires. ConfirmHold turns it into a reservation.
+func (rs *RentalSystem) PlaceHold(req ReservationRequest) (*models.Hold, error) {
+	rs.mu.Lock()
+	defer rs.unlock()
+
+	start, end, err := rs.parseRentalPeriod(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	customer, err := findCustomer(rs.repo, req.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	if req.CarID == 0 {
+		return nil, ErrHoldNeedsCar
+	}
+	car, err := findCar(rs.repo, req.CarID)
+	if errors.Is(err, ErrCarNotFound) {
+		return nil, ErrCarNotAvailable
+	}
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := rs.carBookable(car, start, end, 0); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrCarNotAvailable
+	}
+	pickup, dropoff, err := rs.resolveRoute(car, req.PickupBranchID, req.DropoffBranchID, start, end, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := rs.checkEligibility(customer, car, start, end); err != nil {
+		return nil, err
+	}
+	extras, err := rs.resolveExtras(req.Extras, nil, pickup, start, end, 0)
+	if err != nil {
+		return nil, err
+	}
+	// The promo code and points are tried on the booking the hold would
+	// become, so confirming it does not fail on them later.
+	trial := &models.Reservation{
+		CustomerID:      customer.ID,
+		CarID:           car.ID,
+		VehicleClass:    car.Class,
+		StartDate:       start,
+		EndDate:         end,
+		PickupBranchID:  pickup,
+		DropoffBranchID: dropoff,
+		Extras:          extras,
+	}
+	if err := rs.priceNewReservation(trial, car, customer, req); err != nil {
+		return nil, err
+	}
+
+	now := rs.now()
+	hold := &models.Hold{
+		Status:          models.HoldActive,
+		CustomerID:      customer.ID,
+		CarID:           car.ID,
+		StartDate:       start,
+		EndDate:         end,
+		PickupBranchID:  pickup,
+		DropoffBranchID: dropoff,
+		CreatedAt:       now,
+		ExpiresAt:       now.Add(rs.holdDuration),
+		RedeemPoints:    req.RedeemPoints,
+		Extras:          extras,
+	}
+	if trial.Promo != nil {
+		hold.PromoCode = trial.Promo.Code
+	}
+	if err := rs.repo.SaveHold(hold); err != nil {
+		return nil, err
+	}
+	rs.calendar.hold(hold)
+	rs.publish(events.HoldPlaced{Hold: *hold, At: now})
+	return hold, nil
+}
+
+// ConfirmHold books the held car with the promo code, points and extras
+// the hold was placed with. The reservation is priced and checked as if it
+// were made now; a hold that has run out can no longer be confirmed, even
+// if the reaper has not got to it yet.
+func (rs *RentalSystem) ConfirmHold(holdID int) (*models.Reservation, error) {
+	rs.mu.Lock()
+	defer rs.unlock()
+
+	hold, err := findHold(rs.repo, holdID)
+	if err != nil {
+		return nil, err
+	}
+	if hold.Status != models.HoldActive {
+		return nil, ErrHoldNotActive
+	}
+	if !rs.now().Before(hold.ExpiresAt) {
+		if err := rs.expireHold(hold); err != nil {
+			return nil, err
+		}
+		rs.offerWaitlist()
+		return nil, ErrHoldExpired
+	}
+
+	req := ReservationRequest{
+		CustomerID:      hold.CustomerID,
+		CarID:           hold.CarID,
+		StartDate:       hold.StartDate.Format(time.RFC3339),
+		EndDate:         hold.EndDate.Format(time.RFC3339),
+		PickupBranchID:  hold.PickupBranchID,
+		DropoffBranchID: hold.DropoffBranchID,
+		Extras:          extraRequests(hold.Extras),
+		PromoCode:       hold.PromoCode,
+		RedeemPoints:    hold.RedeemPoints,
+	}
+	// The reservation takes the hold's place in the calendar; the hold is
+	// put back if the booking fails after all.
+	rs.calendar.releaseHold(hold)
+	res, err := rs.reserve(req, func(tx repository.Repository, res *models.Reservation) error {
+		confirmed := *hold
+		confirmed.Status = models.HoldConfirmed
+		confirmed.ReservationID = res.ID
+		return tx.SaveHold(&confirmed)
+	})
+	if err != nil {
+		rs.calendar.hold(hold)
+		return nil, err
+	}
+	return res, nil
+}
+
+// ReleaseHold gives the car back before the hold runs out, e.g. when the
+// customer abandons the booking.
+func (rs *RentalSystem) ReleaseHold(holdID int) error {
+	rs.mu.Lock()
+	defer rs.unlock()
+
+	hold, err := findHold(rs.repo, holdID)
+	if err != nil {
+		return err
+	}
+	if hold.Status != models.HoldActive {
+		return ErrHoldNotActive
+	}
+	hold.Status = models.HoldReleased
+	if err := rs.repo.SaveHold(hold); err != nil {
+		return err
+	}
+	rs.calendar.releaseHold(hold)
+	rs.offerWaitlist()
+	return nil
+}
+
+func (rs *RentalSystem) GetHold(holdID int) (*models.Hold, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return findHold(rs.repo, holdID)
+}
+
+// expireHold closes a hold that ran out and frees its car.
+func (rs *RentalSystem) expireHold(hold *models.Hold) error {
+	hold.Status = models.HoldExpired
+	if err := rs.repo.SaveHold(hold); err != nil {
+		return err
+	}
+	rs.calendar.releaseHold(hold)
+	rs.publish(events.HoldExpired{Hold: *hold, At: rs.now()})
+	return nil
+}
+
+// expireHolds closes every active hold whose time is up and returns how
+// many there were.
+func (rs *RentalSystem) expireHolds() (int, error) {
+	holds, err := rs.repo.Holds()
+	if err != nil {
+		return 0, err
+	}
+	now := rs.now()
+	expired := 0
+	for i := range holds {
+		hold := &holds[i]
+		if hold.Status != models.HoldActive || now.Before(hold.ExpiresAt) {
+			continue
+		}
+		if err := rs.expireHold(hold); err != nil {
+			return expired, err
+		}
+		expired++
+	}
+	return expired, nil
+}
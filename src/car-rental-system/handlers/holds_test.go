@@ -0,0 +1,206 @@
+package services
+
+import (
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"errors"
+	"testing"
+	"time"
+)
+
+// addBookingOptions adds a promo code that can be used once and an
+// insurance extra to rs.
+func addBookingOptions(t *testing.T, rs *RentalSystem) {
+	t.Helper()
+	if _, err := rs.AddPromoCode(models.PromoCode{Code: "spring10", Description: "Spring campaign", Kind: models.DiscountPercent, Value: 10, MaxUses: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rs.AddExtra(models.Extra{Code: "cdw", Name: "Collision damage waiver", Kind: models.ExtraInsurance, Pricing: models.ExtraPerDay, Price: money.New(18_00, money.USD)}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConfirmHoldKeepsBookingOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		promo   string
+		extras  []ExtraRequest
+		wantErr error
+	}{
+		{"promo code and extra", "spring10", []ExtraRequest{{Code: "cdw", Quantity: 1}}, nil},
+		{"unknown promo code", "WINTER", nil, ErrPromoNotFound},
+		{"unknown extra", "", []ExtraRequest{{Code: "gps", Quantity: 1}}, ErrExtraNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs, customer := newTestSystem(t)
+			addBookingOptions(t, rs)
+
+			hold, err := rs.PlaceHold(ReservationRequest{
+				CustomerID: customer.ID,
+				CarID:      1,
+				StartDate:  "2025-03-10",
+				EndDate:    "2025-03-12",
+				PromoCode:  tt.promo,
+				Extras:     tt.extras,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PlaceHold: got %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			res, err := rs.ConfirmHold(hold.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Promo == nil || res.Promo.Code != "SPRING10" {
+				t.Errorf("promo = %+v, want SPRING10", res.Promo)
+			}
+			if len(res.Extras) != 1 || res.Extras[0].Code != "cdw" {
+				t.Errorf("extras = %+v, want cdw", res.Extras)
+			}
+			// The confirmed booking used up the code.
+			_, err = rs.PlaceHold(ReservationRequest{CustomerID: customer.ID, CarID: 2, StartDate: "2025-03-10", EndDate: "2025-03-12", PromoCode: "spring10"})
+			if !errors.Is(err, ErrPromoUsedUp) {
+				t.Errorf("second hold with the code: got %v, want %v", err, ErrPromoUsedUp)
+			}
+		})
+	}
+}
+
+func TestAcceptWaitlistOfferKeepsBookingOptions(t *testing.T) {
+	rs, customer := newTestSystem(t, WithWaitlistHold(time.Hour))
+	addBookingOptions(t, rs)
+	other, err := rs.RegisterCustomer(models.Customer{
+		Name:           "Bo Chen",
+		Email:          "bo@example.com",
+		DateOfBirth:    time.Date(1985, time.June, 5, 0, 0, 0, 0, time.UTC),
+		DriversLicense: "D1234567",
+		LicenseRegion:  "US-CA",
+		LicenseExpiry:  time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	booked, err := rs.CreateReservation(ReservationRequest{CustomerID: other.ID, CarID: 1, StartDate: "2025-03-10", EndDate: "2025-03-12"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, err := rs.JoinWaitlist(ReservationRequest{
+		CustomerID: customer.ID,
+		CarID:      1,
+		StartDate:  "2025-03-10",
+		EndDate:    "2025-03-12",
+		PromoCode:  "Spring10",
+		Extras:     []ExtraRequest{{Code: "cdw", Quantity: 1}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.PromoCode != "SPRING10" || len(entry.Extras) != 1 {
+		t.Fatalf("entry = %+v, want the promo code and extra kept", entry)
+	}
+	if _, err := rs.CancelReservation(booked.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := rs.AcceptWaitlistOffer(entry.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Promo == nil || res.Promo.Code != "SPRING10" {
+		t.Errorf("promo = %+v, want SPRING10", res.Promo)
+	}
+	if len(res.Extras) != 1 || res.Extras[0].Code != "cdw" {
+		t.Errorf("extras = %+v, want cdw", res.Extras)
+	}
+}
+
+func TestJoinWaitlistRejectsUnknownOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     ReservationRequest
+		wantErr error
+	}{
+		{"unknown promo code", ReservationRequest{PromoCode: "WINTER"}, ErrPromoNotFound},
+		{"negative points", ReservationRequest{RedeemPoints: -5}, ErrInvalidPoints},
+		{"unknown extra", ReservationRequest{Extras: []ExtraRequest{{Code: "gps", Quantity: 1}}}, ErrExtraNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs, customer := newTestSystem(t)
+			addBookingOptions(t, rs)
+			req := tt.req
+			req.CustomerID, req.VehicleClass, req.StartDate, req.EndDate = customer.ID, "economy", "2025-03-10", "2025-03-12"
+			if _, err := rs.JoinWaitlist(req); !errors.Is(err, tt.wantErr) {
+				t.Errorf("JoinWaitlist: got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHoldsAndOffersKeepExtrasBack(t *testing.T) {
+	seats := []ExtraRequest{{Code: "child_seat", Quantity: 2}}
+	tests := []struct {
+		name string
+		// keep holds both child seats on car 1 for the customer and
+		// returns how to book them.
+		keep func(t *testing.T, rs *RentalSystem, customer, other *models.Customer) func() (*models.Reservation, error)
+	}{
+		{"hold", func(t *testing.T, rs *RentalSystem, customer, other *models.Customer) func() (*models.Reservation, error) {
+			hold, err := rs.PlaceHold(ReservationRequest{CustomerID: customer.ID, CarID: 1, StartDate: "2025-03-10", EndDate: "2025-03-12", Extras: seats})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return func() (*models.Reservation, error) { return rs.ConfirmHold(hold.ID) }
+		}},
+		{"waitlist offer", func(t *testing.T, rs *RentalSystem, customer, other *models.Customer) func() (*models.Reservation, error) {
+			booked, err := rs.CreateReservation(ReservationRequest{CustomerID: other.ID, CarID: 1, StartDate: "2025-03-10", EndDate: "2025-03-12"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			entry, err := rs.JoinWaitlist(ReservationRequest{CustomerID: customer.ID, CarID: 1, StartDate: "2025-03-10", EndDate: "2025-03-12", Extras: seats})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := rs.CancelReservation(booked.ID); err != nil {
+				t.Fatal(err)
+			}
+			if entry, err = rs.GetWaitlistEntry(entry.ID); err != nil || entry.Status != models.WaitlistOffered {
+				t.Fatalf("entry = %+v, %v; want an offer", entry, err)
+			}
+			return func() (*models.Reservation, error) { return rs.AcceptWaitlistOffer(entry.ID) }
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs, customer := newExtrasTestSystem(t)
+			other, err := rs.RegisterCustomer(models.Customer{
+				Name:           "Bo Chen",
+				Email:          "bo@example.com",
+				DateOfBirth:    time.Date(1985, time.June, 5, 0, 0, 0, 0, time.UTC),
+				DriversLicense: "D1234567",
+				LicenseRegion:  "US-CA",
+				LicenseExpiry:  time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			book := tt.keep(t, rs, customer, other)
+
+			_, err = rs.CreateReservation(ReservationRequest{CustomerID: other.ID, CarID: 2, StartDate: "2025-03-11", EndDate: "2025-03-13", Extras: []ExtraRequest{{Code: "child_seat", Quantity: 1}}})
+			if !errors.Is(err, ErrExtraSoldOut) {
+				t.Errorf("booking a held seat: got %v, want %v", err, ErrExtraSoldOut)
+			}
+			res, err := book()
+			if err != nil {
+				t.Fatalf("booking what was kept: %v", err)
+			}
+			if len(res.Extras) != 1 || res.Extras[0].Quantity != 2 {
+				t.Errorf("extras = %+v, want both child seats", res.Extras)
+			}
+		})
+	}
+}
@@ -0,0 +1,342 @@
+package services
+
+import (
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"car-rental-system/repository"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Reasons recorded on invoices and credit notes.
+const (
+	reasonReservationCreated   = "reservation created"
+	reasonReservationModified  = "reservation modified"
+	reasonReservationCancelled = "reservation cancelled"
+	reasonVehicleReturned      = "vehicle returned"
+	reasonExtrasChanged        = "extras changed"
+)
+
+var invoicePrefixes = map[models.InvoiceKind]string{
+	models.InvoiceKindInvoice:    "INV",
+	models.InvoiceKindCreditNote: "CN",
+}
+
+func findInvoice(repo repository.Repository, invoiceID int) (*models.Invoice, error) {
+	invoice, err := repo.Invoice(invoiceID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrInvoiceNotFound
+	}
+	return invoice, err
+}
+
+// lineKey identifies a price line across invoices, so what was billed can
+// be compared with what is owed now.
+type lineKey struct {
+	code        string
+	description string
+}
+
+// billableLines is what the reservation should have been invoiced for in
+// total: its price breakdown, or only the fee once it is cancelled.
+func billableLines(res *models.Reservation) []models.PriceLine {
+	if res.Status != models.ReservationCancelled {
+		return res.PriceBreakdown
+	}
+	if !res.CancellationFee.IsPositive() {
+		return nil
+	}
+	return []models.PriceLine{{
+		Code:        "cancellation_fee",
+		Description: "Cancellation fee",
+		Quantity:    1,
+		UnitPrice:   res.CancellationFee,
+		Amount:      res.CancellationFee,
+	}}
+}
+
+// lineDiff returns the line that takes billed to target, or false when
+// they are the same.
+func lineDiff(key lineKey, billed, target models.PriceLine) (models.PriceLine, bool) {
+	amount := target.Amount.Sub(billed.Amount)
+	if amount.IsZero() {
+		return models.PriceLine{}, false
+	}
+	line := models.PriceLine{Code: key.code, Description: key.description, Quantity: 1, UnitPrice: amount, Amount: amount}
+	// Show a change in days as days when that accounts for all of it.
+	unitPrice := target.UnitPrice
+	if target.Quantity == 0 {
+		unitPrice = billed.UnitPrice
+	} else if billed.Quantity != 0 && billed.UnitPrice.Cmp(target.UnitPrice) != 0 {
+		return line, true
+	}
+	if quantity := target.Quantity - billed.Quantity; quantity != 0 && unitPrice.Mul(quantity).Cmp(amount) == 0 {
+		line.Quantity, line.UnitPrice = quantity, unitPrice
+	}
+	return line, true
+}
+
+// taxLines splits the taxes out of a tax-inclusive total. Rounding
+// differences go to the last rate so the parts always add up.
+func (rs *RentalSystem) taxLines(total money.Money) (subtotal money.Money, taxes []models.TaxLine, taxTotal money.Money) {
+	percent := 0.0
+	for _, rate := range rs.taxRates {
+		percent += rate.Percent
+	}
+	subtotal = total.Div(1+percent/100, money.HalfUp)
+	remaining := total.Sub(subtotal)
+	for i, rate := range rs.taxRates {
+		amount := subtotal.Percent(rate.Percent, money.HalfUp)
+		if i == len(rs.taxRates)-1 {
+			amount = remaining
+		}
+		remaining = remaining.Sub(amount)
+		taxes = append(taxes, models.TaxLine{Name: rate.Name, Percent: rate.Percent, Amount: amount})
+	}
+	return subtotal, taxes, total.Sub(subtotal)
+}
+
+// nextInvoiceSequence returns the next number in the kind's sequence.
+func nextInvoiceSequence(repo repository.Repository, kind models.InvoiceKind) (int, error) {
+	invoices, err := repo.Invoices()
+	if err != nil {
+		return 0, err
+	}
+	last := 0
+	for _, invoice := range invoices {
+		if invoice.Kind == kind {
+			last = max(last, invoice.Sequence)
+		}
+	}
+	return last + 1, nil
+}
+
+// bill brings the reservation's invoices in line with its price. Whatever
+// has not been invoiced yet goes on a new invoice; whatever was invoiced
+// beyond the price is given back on a credit note. Lines are compared one
+// by one, so the new document shows what changed.
+func (rs *RentalSystem) bill(repo repository.Repository, res *models.Reservation, reason string) error {
+	issued, err := repo.InvoicesForReservation(res.ID)
+	if err != nil {
+		return err
+	}
+
+	var order []lineKey
+	billed := make(map[lineKey]models.PriceLine)
+	add := func(to map[lineKey]models.PriceLine, line models.PriceLine, sign int) {
+		key := lineKey{line.Code, line.Description}
+		if _, seen := billed[key]; !seen {
+			if _, seen := to[key]; !seen {
+				order = append(order, key)
+			}
+		}
+		sum := to[key]
+		sum.Quantity += sign * line.Quantity
+		sum.UnitPrice = line.UnitPrice
+		sum.Amount = sum.Amount.Add(line.Amount.Mul(sign))
+		to[key] = sum
+	}
+	for _, invoice := range issued {
+		sign := 1
+		if invoice.Kind == models.InvoiceKindCreditNote {
+			sign = -1
+		}
+		for _, line := range invoice.Lines {
+			// Invoices are issued in the reservation's currency only.
+			if !line.Amount.SameCurrency(res.TotalPrice) {
+				return ErrCurrencyMismatch
+			}
+			add(billed, line, sign)
+		}
+	}
+	target := make(map[lineKey]models.PriceLine)
+	for _, line := range billableLines(res) {
+		add(target, line, 1)
+	}
+
+	var lines []models.PriceLine
+	net := money.New(0, res.TotalPrice.Currency)
+	for _, key := range order {
+		if line, changed := lineDiff(key, billed[key], target[key]); changed {
+			lines = append(lines, line)
+			net = net.Add(line.Amount)
+		}
+	}
+	if net.IsZero() {
+		return nil
+	}
+
+	kind := models.InvoiceKindInvoice
+	if net.IsNegative() {
+		kind = models.InvoiceKindCreditNote
+		net = net.Neg()
+		for i := range lines {
+			if lines[i].Quantity < 0 {
+				lines[i].Quantity = -lines[i].Quantity
+			} else {
+				lines[i].UnitPrice = lines[i].UnitPrice.Neg()
+			}
+			lines[i].Amount = lines[i].Amount.Neg()
+		}
+	}
+	customer, err := findCustomer(repo, res.CustomerID)
+	if err != nil {
+		return err
+	}
+	sequence, err := nextInvoiceSequence(repo, kind)
+	if err != nil {
+		return err
+	}
+
+	invoice := &models.Invoice{
+		Number:        fmt.Sprintf("%s-%06d", invoicePrefixes[kind], sequence),
+		Kind:          kind,
+		Sequence:      sequence,
+		ReservationID: res.ID,
+		CustomerID:    customer.ID,
+		BillTo:        models.BillingParty{Name: customer.Name, Email: customer.Email, Address: customer.Address},
+		IssuedAt:      rs.now(),
+		Reason:        reason,
+		Lines:         lines,
+		Total:         net,
+	}
+	invoice.Subtotal, invoice.Taxes, invoice.TaxTotal = rs.taxLines(net)
+	return repo.SaveInvoice(invoice)
+}
+
+// settleInvoices fills in how much of each of a reservation's invoices has
+// been settled. Payments and credit notes are set against the invoices
+// oldest first.
+func settleInvoices(invoices []models.Invoice, res *models.Reservation) {
+	available := res.AmountPaid
+	for _, invoice := range invoices {
+		if invoice.Kind == models.InvoiceKindCreditNote {
+			available = available.Add(invoice.Total)
+		}
+	}
+	for i := range invoices {
+		invoice := &invoices[i]
+		if invoice.Kind == models.InvoiceKindCreditNote {
+			invoice.AmountSettled = invoice.Total
+			invoice.Status = models.CreditNoteApplied
+			continue
+		}
+		invoice.AmountSettled = money.Min(invoice.Total, available)
+		if invoice.AmountSettled.IsNegative() {
+			invoice.AmountSettled = money.New(0, invoice.Total.Currency)
+		}
+		available = available.Sub(invoice.AmountSettled)
+		switch {
+		case invoice.AmountSettled.Cmp(invoice.Total) >= 0:
+			invoice.Status = models.InvoicePaid
+		case invoice.AmountSettled.IsPositive():
+			invoice.Status = models.InvoicePartiallyPaid
+		default:
+			invoice.Status = models.InvoiceUnpaid
+		}
+	}
+}
+
+// reservationInvoices loads a reservation's invoices with their payment
+// status.
+func (rs *RentalSystem) reservationInvoices(reservationID int) ([]models.Invoice, error) {
+	res, err := findReservation(rs.repo, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	invoices, err := rs.repo.InvoicesForReservation(reservationID)
+	if err != nil {
+		return nil, err
+	}
+	settleInvoices(invoices, res)
+	return invoices, nil
+}
+
+// Invoices returns the invoices and credit notes of a reservation in the
+// order they were issued.
+func (rs *RentalSystem) Invoices(reservationID int) ([]models.Invoice, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return rs.reservationInvoices(reservationID)
+}
+
+func (rs *RentalSystem) GetInvoice(invoiceID int) (*models.Invoice, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	invoice, err := findInvoice(rs.repo, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	// The status depends on the reservation's other invoices too.
+	invoices, err := rs.reservationInvoices(invoice.ReservationID)
+	if errors.Is(err, ErrReservationNotFound) {
+		return invoice, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, inv := range invoices {
+		if inv.ID == invoiceID {
+			return &inv, nil
+		}
+	}
+	return invoice, nil
+}
+
+// IssuedInvoices returns every invoice and credit note issued on or after
+// fromDate and before toDate, for accounting. Either date may be empty to
+// leave that end open.
+func (rs *RentalSystem) IssuedInvoices(fromDate, toDate string) ([]models.Invoice, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	var from, to time.Time
+	var err error
+	if fromDate != "" {
+		if from, err = parseDate(fromDate); err != nil {
+			return nil, err
+		}
+	}
+	if toDate != "" {
+		if to, err = parseDate(toDate); err != nil {
+			return nil, err
+		}
+	}
+
+	all, err := rs.repo.Invoices()
+	if err != nil {
+		return nil, err
+	}
+	byReservation := make(map[int][]models.Invoice)
+	var order []int
+	for _, invoice := range all {
+		if _, seen := byReservation[invoice.ReservationID]; !seen {
+			order = append(order, invoice.ReservationID)
+		}
+		byReservation[invoice.ReservationID] = append(byReservation[invoice.ReservationID], invoice)
+	}
+	settled := make(map[int]models.Invoice, len(all))
+	for _, id := range order {
+		invoices := byReservation[id]
+		res, err := findReservation(rs.repo, id)
+		if err == nil {
+			settleInvoices(invoices, res)
+		} else if !errors.Is(err, ErrReservationNotFound) {
+			return nil, err
+		}
+		for _, invoice := range invoices {
+			settled[invoice.ID] = invoice
+		}
+	}
+
+	var result []models.Invoice
+	for _, invoice := range all {
+		if (!from.IsZero() && invoice.IssuedAt.Before(from)) || (!to.IsZero() && !invoice.IssuedAt.Before(to)) {
+			continue
+		}
+		result = append(result, settled[invoice.ID])
+	}
+	return result, nil
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"errors"
+	"testing"
+)
+
+func priceLine(code string, quantity int, unitPrice money.Money) models.PriceLine {
+	return models.PriceLine{Code: code, Description: code, Quantity: quantity, UnitPrice: unitPrice, Amount: unitPrice.Mul(quantity)}
+}
+
+func issuedDocument(kind models.InvoiceKind, lines ...models.PriceLine) models.Invoice {
+	total := money.New(0, lines[0].Amount.Currency)
+	for _, line := range lines {
+		total = total.Add(line.Amount)
+	}
+	return models.Invoice{Kind: kind, ReservationID: 1, Lines: lines, Total: total}
+}
+
+func TestBill(t *testing.T) {
+	usd := func(amount int64) money.Money { return money.New(amount, money.USD) }
+	active := func(lines ...models.PriceLine) models.Reservation {
+		return models.Reservation{Status: models.ReservationActive, PriceBreakdown: lines}
+	}
+	threeDays := issuedDocument(models.InvoiceKindInvoice, priceLine("rental_days", 3, usd(50_00)))
+	withGPS := issuedDocument(models.InvoiceKindInvoice, priceLine("rental_days", 3, usd(50_00)), priceLine("extra_gps", 1, usd(15_00)))
+
+	tests := []struct {
+		name    string
+		issued  []models.Invoice
+		res     models.Reservation
+		number  string
+		lines   []models.PriceLine
+		total   money.Money
+		tax     money.Money
+		wantErr error
+	}{
+		{
+			name:   "first invoice",
+			res:    active(priceLine("rental_days", 3, usd(50_00))),
+			number: "INV-000001",
+			lines:  []models.PriceLine{priceLine("rental_days", 3, usd(50_00))},
+			total:  usd(150_00),
+			tax:    usd(25_00),
+		},
+		{
+			name:   "nothing changed",
+			issued: []models.Invoice{threeDays},
+			res:    active(priceLine("rental_days", 3, usd(50_00))),
+		},
+		{
+			name:   "extra day",
+			issued: []models.Invoice{threeDays},
+			res:    active(priceLine("rental_days", 4, usd(50_00))),
+			number: "INV-000002",
+			lines:  []models.PriceLine{priceLine("rental_days", 1, usd(50_00))},
+			total:  usd(50_00),
+			tax:    usd(8_33),
+		},
+		{
+			name:   "day less",
+			issued: []models.Invoice{threeDays},
+			res:    active(priceLine("rental_days", 2, usd(50_00))),
+			number: "CN-000001",
+			lines:  []models.PriceLine{priceLine("rental_days", 1, usd(50_00))},
+			total:  usd(50_00),
+			tax:    usd(8_33),
+		},
+		{
+			name:   "new daily rate",
+			issued: []models.Invoice{threeDays},
+			res:    active(priceLine("rental_days", 3, usd(55_00))),
+			number: "INV-000002",
+			lines:  []models.PriceLine{priceLine("rental_days", 1, usd(15_00))},
+			total:  usd(15_00),
+			tax:    usd(2_50),
+		},
+		{
+			name:   "extra removed",
+			issued: []models.Invoice{withGPS},
+			res:    active(priceLine("rental_days", 3, usd(50_00))),
+			number: "CN-000001",
+			lines:  []models.PriceLine{priceLine("extra_gps", 1, usd(15_00))},
+			total:  usd(15_00),
+			tax:    usd(2_50),
+		},
+		{
+			name:   "credit note already issued",
+			issued: []models.Invoice{threeDays, issuedDocument(models.InvoiceKindCreditNote, priceLine("rental_days", 1, usd(50_00)))},
+			res:    active(priceLine("rental_days", 2, usd(50_00))),
+		},
+		{
+			name:   "cancelled with a fee",
+			issued: []models.Invoice{threeDays},
+			res: models.Reservation{
+				Status:          models.ReservationCancelled,
+				PriceBreakdown:  []models.PriceLine{priceLine("rental_days", 3, usd(50_00))},
+				CancellationFee: usd(30_00),
+			},
+			number: "CN-000001",
+			lines: []models.PriceLine{
+				priceLine("rental_days", 3, usd(50_00)),
+				{Code: "cancellation_fee", Description: "Cancellation fee", Quantity: 1, UnitPrice: usd(-30_00), Amount: usd(-30_00)},
+			},
+			total: usd(120_00),
+			tax:   usd(20_00),
+		},
+		{
+			name:    "invoice in another currency",
+			issued:  []models.Invoice{issuedDocument(models.InvoiceKindInvoice, priceLine("rental_days", 3, money.New(45_00, money.EUR)))},
+			res:     active(priceLine("rental_days", 3, usd(50_00))),
+			wantErr: ErrCurrencyMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs, customer := newTestSystem(t, WithTaxRates(models.TaxRate{Name: "VAT", Percent: 20}))
+			for i := range tt.issued {
+				invoice := tt.issued[i]
+				invoice.Sequence = 1
+				if err := rs.repo.SaveInvoice(&invoice); err != nil {
+					t.Fatal(err)
+				}
+			}
+			res := tt.res
+			res.ID, res.CustomerID = 1, customer.ID
+			res.TotalPrice = money.New(0, money.USD)
+			for _, line := range billableLines(&res) {
+				res.TotalPrice = res.TotalPrice.Add(line.Amount)
+			}
+
+			err := rs.bill(rs.repo, &res, reasonReservationModified)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("bill: got %v, want %v", err, tt.wantErr)
+			}
+			all, err := rs.repo.InvoicesForReservation(res.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.number == "" {
+				if len(all) != len(tt.issued) {
+					t.Fatalf("got %d documents, want none issued", len(all)-len(tt.issued))
+				}
+				return
+			}
+			if len(all) != len(tt.issued)+1 {
+				t.Fatalf("got %d documents, want one issued", len(all)-len(tt.issued))
+			}
+			got := all[len(all)-1]
+			if got.Number != tt.number || got.Total != tt.total {
+				t.Errorf("issued %s for %v, want %s for %v", got.Number, got.Total, tt.number, tt.total)
+			}
+			if got.TaxTotal != tt.tax || got.Subtotal.Add(got.TaxTotal) != got.Total || len(got.Taxes) != 1 || got.Taxes[0].Amount != tt.tax {
+				t.Errorf("taxes %v of %v on subtotal %v, want %v", got.Taxes, got.TaxTotal, got.Subtotal, tt.tax)
+			}
+			if len(got.Lines) != len(tt.lines) {
+				t.Fatalf("lines = %v, want %v", got.Lines, tt.lines)
+			}
+			for i := range got.Lines {
+				if got.Lines[i] != tt.lines[i] {
+					t.Errorf("line %d = %v, want %v", i, got.Lines[i], tt.lines[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSettleInvoices(t *testing.T) {
+	usd := func(amount int64) money.Money { return money.New(amount, money.USD) }
+	invoice := func(amount int64) models.Invoice {
+		return models.Invoice{Kind: models.InvoiceKindInvoice, Total: usd(amount)}
+	}
+	creditNote := func(amount int64) models.Invoice {
+		return models.Invoice{Kind: models.InvoiceKindCreditNote, Total: usd(amount)}
+	}
+	type settled struct {
+		amount money.Money
+		status models.InvoiceStatus
+	}
+
+	tests := []struct {
+		name     string
+		invoices []models.Invoice
+		paid     money.Money
+		want     []settled
+	}{
+		{"unpaid", []models.Invoice{invoice(100_00)}, usd(0), []settled{{usd(0), models.InvoiceUnpaid}}},
+		{"partly paid", []models.Invoice{invoice(100_00)}, usd(40_00), []settled{{usd(40_00), models.InvoicePartiallyPaid}}},
+		{"paid", []models.Invoice{invoice(100_00)}, usd(100_00), []settled{{usd(100_00), models.InvoicePaid}}},
+		{
+			"oldest first",
+			[]models.Invoice{invoice(100_00), invoice(50_00)},
+			usd(120_00),
+			[]settled{{usd(100_00), models.InvoicePaid}, {usd(20_00), models.InvoicePartiallyPaid}},
+		},
+		{
+			"credit note counts as paid",
+			[]models.Invoice{invoice(100_00), creditNote(30_00), invoice(20_00)},
+			usd(70_00),
+			[]settled{{usd(100_00), models.InvoicePaid}, {usd(30_00), models.CreditNoteApplied}, {usd(0), models.InvoiceUnpaid}},
+		},
+		{
+			"refund owed leaves nothing negative",
+			[]models.Invoice{invoice(100_00), creditNote(100_00), invoice(30_00)},
+			usd(-50_00),
+			[]settled{{usd(50_00), models.InvoicePartiallyPaid}, {usd(100_00), models.CreditNoteApplied}, {usd(0), models.InvoiceUnpaid}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settleInvoices(tt.invoices, &models.Reservation{AmountPaid: tt.paid})
+			for i, want := range tt.want {
+				got := settled{tt.invoices[i].AmountSettled, tt.invoices[i].Status}
+				if got != want {
+					t.Errorf("document %d settled %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package services
+
+import (
+	"car-rental-system/events"
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"car-rental-system/repository"
+	"math"
+)
+
+// LoyaltyPolicy sets how points are earned and spent and what it takes to
+// reach each tier. Tier benefits that change the price, such as a waived
+// young-driver fee, are pricing rules keyed on the tier instead. The
+// ledger is kept in the base currency of the currency policy, and the
+// policy's amounts must be in it too.
+type LoyaltyPolicy struct {
+	// PointsPerUnit is awarded for every whole unit of the base currency a
+	// completed, paid rental cost.
+	PointsPerUnit float64
+	// PointValue is what one point takes off a booking.
+	PointValue money.Money
+	// TierWindowMonths is how far back rentals count toward a tier.
+	TierWindowMonths int
+	// Tiers are checked in order and the first one the customer qualifies
+	// for applies, so list the highest first.
+	Tiers []TierRule
+}
+
+// TierRule is what a customer needs within the tier window to reach Tier:
+// MinRentals completed, paid rentals or MinSpend spent on them. A zero
+// threshold is not checked.
+type TierRule struct {
+	Tier       models.LoyaltyTier
+	MinRentals int
+	MinSpend   money.Money
+	// FreeUpgrade gives class bookings a car from the next class up when
+	// one is free, still at the booked class's rate.
+	FreeUpgrade bool
+}
+
+// DefaultLoyaltyPolicy awards a point per 1.00 of base spent, worth 0.01
+// each, or the currency's smallest unit when it has no cents. Three
+// rentals or 1000.00 a year make silver; ten rentals or 5000.00 make gold,
+// which comes with free upgrades.
+func DefaultLoyaltyPolicy(base money.Currency) LoyaltyPolicy {
+	units := func(amount string) money.Money {
+		m, _ := money.Parse(amount, base)
+		return m
+	}
+	pointValue, err := money.Parse("0.01", base)
+	if err != nil {
+		pointValue = money.New(1, base)
+	}
+	return LoyaltyPolicy{
+		PointsPerUnit:    1,
+		PointValue:       pointValue,
+		TierWindowMonths: 12,
+		Tiers: []TierRule{
+			{Tier: models.TierGold, MinRentals: 10, MinSpend: units("5000"), FreeUpgrade: true},
+			{Tier: models.TierSilver, MinRentals: 3, MinSpend: units("1000")},
+		},
+	}
+}
+
+// validate checks the policy's amounts are in base, giving those without
+// a currency base's, and that nothing is negative.
+func (p *LoyaltyPolicy) validate(base money.Currency) error {
+	amounts := []*money.Money{&p.PointValue}
+	for i := range p.Tiers {
+		amounts = append(amounts, &p.Tiers[i].MinSpend)
+	}
+	for _, amount := range amounts {
+		if amount.Currency == "" {
+			amount.Currency = base
+		}
+		if amount.Currency != base || amount.IsNegative() {
+			return ErrInvalidLoyaltyPolicy
+		}
+	}
+	if p.PointsPerUnit < 0 || p.TierWindowMonths < 0 {
+		return ErrInvalidLoyaltyPolicy
+	}
+	return nil
+}
+
+func (p LoyaltyPolicy) tierFor(rentals int, spend money.Money) models.LoyaltyTier {
+	for _, rule := range p.Tiers {
+		if (rule.MinRentals > 0 && rentals >= rule.MinRentals) || (rule.MinSpend.IsPositive() && spend.Cmp(rule.MinSpend) >= 0) {
+			return rule.Tier
+		}
+	}
+	return models.TierMember
+}
+
+func (p LoyaltyPolicy) freeUpgrade(tier models.LoyaltyTier) bool {
+	for _, rule := range p.Tiers {
+		if rule.Tier == tier {
+			return rule.FreeUpgrade
+		}
+	}
+	return false
+}
+
+// LoyaltyAccount is a customer's points balance and tier, with the ledger
+// they were worked out from.
+type LoyaltyAccount struct {
+	CustomerID int                `json:"customerId"`
+	Tier       models.LoyaltyTier `json:"tier"`
+	Points     int                `json:"points"`
+	// RecentRentals and RecentSpend are the completed, paid rentals within
+	// the tier window, which the tier is based on.
+	RecentRentals int                   `json:"recentRentals"`
+	RecentSpend   money.Money           `json:"recentSpend"`
+	Entries       []models.LoyaltyEntry `json:"entries"`
+}
+
+// loyaltyAccount adds up the customer's ledger as of now.
+func (rs *RentalSystem) loyaltyAccount(repo repository.Repository, customerID int) (*LoyaltyAccount, error) {
+	entries, err := repo.LoyaltyEntriesForCustomer(customerID)
+	if err != nil {
+		return nil, err
+	}
+	account := &LoyaltyAccount{CustomerID: customerID, RecentSpend: money.New(0, rs.currencyPolicy.Base), Entries: entries}
+	since := rs.now().AddDate(0, -rs.loyaltyPolicy.TierWindowMonths, 0)
+	for _, entry := range entries {
+		account.Points += entry.Points
+		if entry.Kind == models.LoyaltyEarned && entry.At.After(since) {
+			account.RecentRentals++
+			// Spend is kept in the base currency, which a change of
+			// configuration can leave behind.
+			if account.RecentSpend, err = account.RecentSpend.CheckedAdd(entry.Spend); err != nil {
+				return nil, err
+			}
+		}
+	}
+	account.Tier = rs.loyaltyPolicy.tierFor(account.RecentRentals, account.RecentSpend)
+	return account, nil
+}
+
+// GetLoyaltyAccount returns the customer's points balance, current tier and
+// ledger.
+func (rs *RentalSystem) GetLoyaltyAccount(customerID int) (*LoyaltyAccount, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if _, err := findCustomer(rs.repo, customerID); err != nil {
+		return nil, err
+	}
+	return rs.loyaltyAccount(rs.repo, customerID)
+}
+
+// redeemPoints takes up to points loyalty points off a priced reservation
+// about to be booked, out of the customer's balance. Points beyond what the
+// rental costs are left in the account. Points are worth their value in
+// the base currency, converted at the reservation's rate date.
+func (rs *RentalSystem) redeemPoints(res *models.Reservation, car *models.Car, customer *models.Customer, balance, points int) error {
+	if points == 0 {
+		return nil
+	}
+	value := rs.loyaltyPolicy.PointValue
+	if points < 0 || !value.IsPositive() {
+		return ErrInvalidPoints
+	}
+	if points > balance {
+		return ErrInsufficientPoints
+	}
+
+	exchange, err := rs.exchange(rs.repo, res.RateDate)
+	if err != nil {
+		return err
+	}
+	total, err := exchange.Convert(res.TotalPrice, value.Currency)
+	if err != nil {
+		return err
+	}
+	points = min(points, int((total.Amount+value.Amount-1)/value.Amount))
+	discount, err := exchange.Convert(value.Mul(points), res.TotalPrice.Currency)
+	if err != nil {
+		return err
+	}
+	res.PointsRedeemed = points
+	res.LoyaltyDiscount = money.Min(discount, res.TotalPrice)
+	return rs.reprice(res, car, customer)
+}
+
+// recordRedemption writes the points a new reservation was booked with to
+// the ledger.
+func (rs *RentalSystem) recordRedemption(tx repository.Repository, res *models.Reservation) error {
+	if res.PointsRedeemed == 0 {
+		return nil
+	}
+	return tx.SaveLoyaltyEntry(&models.LoyaltyEntry{
+		CustomerID:    res.CustomerID,
+		ReservationID: res.ID,
+		Kind:          models.LoyaltyRedeemed,
+		Points:        -res.PointsRedeemed,
+		At:            rs.now(),
+	})
+}
+
+// reinstatePoints gives back the points redeemed on a cancelled
+// reservation.
+func (rs *RentalSystem) reinstatePoints(tx repository.Repository, res *models.Reservation) error {
+	if res.PointsRedeemed == 0 {
+		return nil
+	}
+	return tx.SaveLoyaltyEntry(&models.LoyaltyEntry{
+		CustomerID:    res.CustomerID,
+		ReservationID: res.ID,
+		Kind:          models.LoyaltyReinstated,
+		Points:        res.PointsRedeemed,
+		At:            rs.now(),
+	})
+}
+
+// awardPoints credits a reservation's points once it is both completed
+// and paid, whichever happens last, and returns the event to publish once
+// the transaction commits. It returns nil when there is nothing to award
+// yet, or the points were already given.
+func (rs *RentalSystem) awardPoints(tx repository.Repository, res *models.Reservation) (*events.LoyaltyPointsEarned, error) {
+	if res.Status != models.ReservationCompleted || res.AmountDue.IsPositive() {
+		return nil, nil
+	}
+	entries, err := tx.LoyaltyEntriesForCustomer(res.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.ReservationID == res.ID && entry.Kind == models.LoyaltyEarned {
+			return nil, nil
+		}
+	}
+
+	spend, err := rs.convert(tx, res.TotalPrice, rs.currencyPolicy.Base, res.RateDate)
+	if err != nil {
+		return nil, err
+	}
+	units := float64(spend.Amount) / math.Pow10(spend.Currency.Exponent())
+	entry := &models.LoyaltyEntry{
+		CustomerID:    res.CustomerID,
+		ReservationID: res.ID,
+		Kind:          models.LoyaltyEarned,
+		Points:        int(math.Floor(units * rs.loyaltyPolicy.PointsPerUnit)),
+		Spend:         spend,
+		At:            rs.now(),
+	}
+	if err := tx.SaveLoyaltyEntry(entry); err != nil {
+		return nil, err
+	}
+	account, err := rs.loyaltyAccount(tx, res.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	return &events.LoyaltyPointsEarned{Entry: *entry, Tier: account.Tier, At: entry.At}, nil
+}
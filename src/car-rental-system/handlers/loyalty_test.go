@@ -0,0 +1,100 @@
+package services
+
+import (
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"car-rental-system/repository"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDefaultLoyaltyPolicy(t *testing.T) {
+	tests := []struct {
+		base           money.Currency
+		wantPointValue money.Money
+		wantGold       money.Money
+	}{
+		{money.USD, money.New(1, money.USD), money.New(5000_00, money.USD)},
+		{money.EUR, money.New(1, money.EUR), money.New(5000_00, money.EUR)},
+		{"JPY", money.New(1, "JPY"), money.New(5000, "JPY")},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.base), func(t *testing.T) {
+			policy := DefaultLoyaltyPolicy(tt.base)
+			if policy.PointValue != tt.wantPointValue {
+				t.Errorf("point value = %v, want %v", policy.PointValue, tt.wantPointValue)
+			}
+			if policy.Tiers[0].MinSpend != tt.wantGold {
+				t.Errorf("gold spend = %v, want %v", policy.Tiers[0].MinSpend, tt.wantGold)
+			}
+			if err := policy.validate(tt.base); err != nil {
+				t.Errorf("validate: %v", err)
+			}
+		})
+	}
+}
+
+func TestLoyaltyPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  LoyaltyPolicy
+		wantErr error
+	}{
+		{"default", DefaultLoyaltyPolicy(money.EUR), nil},
+		{"amounts without a currency", LoyaltyPolicy{PointValue: money.New(1, ""), Tiers: []TierRule{{Tier: models.TierGold, MinSpend: money.New(100_00, "")}}}, nil},
+		{"point value in another currency", LoyaltyPolicy{PointValue: money.New(1, money.USD)}, ErrInvalidLoyaltyPolicy},
+		{"tier spend in another currency", DefaultLoyaltyPolicy(money.USD), ErrInvalidLoyaltyPolicy},
+		{"negative point value", LoyaltyPolicy{PointValue: money.New(-1, money.EUR)}, ErrInvalidLoyaltyPolicy},
+		{"negative points per unit", LoyaltyPolicy{PointsPerUnit: -1}, ErrInvalidLoyaltyPolicy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := tt.policy
+			if err := policy.validate(money.EUR); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && policy.PointValue.Currency != money.EUR {
+				t.Errorf("point value in %s, want EUR", policy.PointValue.Currency)
+			}
+		})
+	}
+}
+
+func TestLoyaltyInConfiguredBaseCurrency(t *testing.T) {
+	euro := CurrencyPolicy{Base: money.EUR, RateDate: RateAtBooking, Rounding: money.HalfEven}
+
+	if _, err := NewRentalSystemWithRepository(repository.NewMemoryRepository(), WithCurrencyPolicy(euro), WithLoyaltyPolicy(DefaultLoyaltyPolicy(money.USD))); !errors.Is(err, ErrInvalidLoyaltyPolicy) {
+		t.Errorf("dollar policy with a euro base: got %v, want %v", err, ErrInvalidLoyaltyPolicy)
+	}
+
+	rs, customer := newTestSystem(t, WithCurrencyPolicy(euro))
+	if _, err := rs.CreateReservation(ReservationRequest{CustomerID: customer.ID, CarID: 1, StartDate: "2025-03-10", EndDate: "2025-03-12"}); err != nil {
+		t.Fatal(err)
+	}
+	account, err := rs.GetLoyaltyAccount(customer.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.Tier != models.TierMember || account.RecentSpend.Currency != money.EUR {
+		t.Errorf("account = %+v, want a member with spend in EUR", account)
+	}
+}
+
+func TestLoyaltyLedgerInOldBaseCurrency(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	_, customer := newTestSystemWithRepository(t, repo)
+	if err := repo.SaveLoyaltyEntry(&models.LoyaltyEntry{CustomerID: customer.ID, Kind: models.LoyaltyEarned, Points: 100, Spend: money.New(100_00, money.USD), At: testNow.AddDate(0, -1, 0)}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The same records under a system reconfigured to a euro base.
+	euro := CurrencyPolicy{Base: money.EUR, RateDate: RateAtBooking, Rounding: money.HalfEven}
+	rs, err := NewRentalSystemWithRepository(repo, WithClock(func() time.Time { return testNow }), WithCurrencyPolicy(euro))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rs.GetLoyaltyAccount(customer.ID); !errors.Is(err, money.ErrCurrencyMismatch) {
+		t.Errorf("GetLoyaltyAccount: got %v, want %v", err, money.ErrCurrencyMismatch)
+	}
+}
@@ -0,0 +1,388 @@
+package services
+
+import (
+	"car-rental-system/events"
+	"car-rental-system/money"
+	"car-rental-system/payments"
+	models "car-rental-system/rental_system_models"
+	"car-rental-system/repository"
+	"errors"
+)
+
+// settleBalance recomputes what is owed on a reservation, or owed back to
+// the customer, from what it is charged and the money captured so far.
+// Both must be in the currency the reservation was booked in.
+func settleBalance(res *models.Reservation) error {
+	currency := res.TotalPrice.Currency
+	if !res.Charged().SameCurrency(res.TotalPrice) || !res.AmountPaid.SameCurrency(res.TotalPrice) {
+		return ErrCurrencyMismatch
+	}
+	if res.AmountPaid.Currency == "" {
+		res.AmountPaid = money.New(0, currency)
+	}
+	res.AmountDue = money.New(0, currency)
+	res.RefundDue = money.New(0, currency)
+	switch diff := res.Charged().Sub(res.AmountPaid); {
+	case diff.IsPositive():
+		res.AmountDue = diff
+	case diff.IsNegative():
+		res.RefundDue = diff.Neg()
+	}
+	res.Paid = res.AmountDue.IsZero()
+	return nil
+}
+
+// paymentAmount checks an amount to charge or refund against the most
+// that can be: amounts without a currency are in limit's, and zero or
+// less means all of limit.
+func paymentAmount(amount, limit money.Money) (money.Money, error) {
+	if err := checkCurrency(&amount, limit.Currency); err != nil {
+		return money.Money{}, err
+	}
+	if !amount.IsPositive() {
+		return limit, nil
+	}
+	if amount.Cmp(limit) > 0 {
+		return money.Money{}, ErrInvalidAmount
+	}
+	return amount, nil
+}
+
+// checkCurrency makes sure amount is in currency, giving it currency when
+// it has none.
+func checkCurrency(amount *money.Money, currency money.Currency) error {
+	if amount.Currency == "" {
+		amount.Currency = currency
+	}
+	parsed, err := money.ParseCurrency(string(amount.Currency))
+	if err != nil {
+		return err
+	}
+	if parsed != currency {
+		return ErrCurrencyMismatch
+	}
+	amount.Currency = parsed
+	return nil
+}
+
+func findPayment(repo repository.Repository, paymentID int) (*models.Payment, error) {
+	payment, err := repo.Payment(paymentID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrPaymentNotFound
+	}
+	return payment, err
+}
+
+// authorize holds amount with the gateway, translating a decline into the
+// rental system's own error.
+func (rs *RentalSystem) authorize(amount money.Money, method string) (string, error) {
+	reference, err := rs.gateway.Authorize(amount, method)
+	if errors.Is(err, payments.ErrDeclined) {
+		return "", ErrPaymentDeclined
+	}
+	return reference, err
+}
+
+// applyCapture books a captured payment against its reservation in one
+// transaction.
+func (rs *RentalSystem) applyCapture(payment *models.Payment) error {
+	var res *models.Reservation
+	var earned *events.LoyaltyPointsEarned
+	err := rs.repo.Transaction(func(tx repository.Repository) error {
+		var err error
+		if res, err = findReservation(tx, payment.ReservationID); err != nil {
+			return err
+		}
+		if res.AmountPaid, err = res.AmountPaid.CheckedAdd(payment.Amount); err != nil {
+			return err
+		}
+		if err := settleBalance(res); err != nil {
+			return err
+		}
+		if err := tx.SaveReservation(res); err != nil {
+			return err
+		}
+		if err := tx.SavePayment(payment); err != nil {
+			return err
+		}
+		// Settling what was owed after the return earns the points.
+		earned, err = rs.awardPoints(tx, res)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	rs.publish(events.PaymentProcessed{Payment: *payment, Reservation: *res, At: payment.UpdatedAt})
+	if earned != nil {
+		rs.publish(*earned)
+	}
+	return nil
+}
+
+// ProcessPayment charges amount to the reservation in one step. An amount
+// of zero or less pays the whole outstanding balance; smaller amounts are
+// partial payments. Payments are taken in the reservation's currency; an
+// amount without a currency is in it.
+func (rs *RentalSystem) ProcessPayment(reservationID int, amount money.Money, method string) (*models.Payment, error) {
+	rs.mu.Lock()
+	defer rs.unlock()
+
+	res, err := findReservation(rs.repo, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	if !res.AmountDue.IsPositive() {
+		return nil, ErrAlreadyPaid
+	}
+	if amount, err = paymentAmount(amount, res.AmountDue); err != nil {
+		return nil, err
+	}
+
+	reference, err := rs.authorize(amount, method)
+	if err != nil {
+		return nil, err
+	}
+	if err := rs.gateway.Capture(reference, amount); err != nil {
+		rs.gateway.Void(reference)
+		return nil, err
+	}
+
+	now := rs.now()
+	payment := &models.Payment{
+		ReservationID:     reservationID,
+		Amount:            amount,
+		RefundedAmount:    money.New(0, amount.Currency),
+		Method:            method,
+		ProviderReference: reference,
+		Status:            models.PaymentCaptured,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if err := rs.applyCapture(payment); err != nil {
+		// Give the money back rather than keep a charge we did not record.
+		rs.gateway.Refund(reference, amount)
+		return nil, err
+	}
+	return payment, nil
+}
+
+// AuthorizePayment places a hold for amount without taking the money yet.
+// Like payments, holds are in the reservation's currency.
+func (rs *RentalSystem) AuthorizePayment(reservationID int, amount money.Money, method string) (*models.Payment, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	res, err := findReservation(rs.repo, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	if res.Status == models.ReservationCancelled {
+		return nil, ErrReservationCancelled
+	}
+	if err := checkCurrency(&amount, res.TotalPrice.Currency); err != nil {
+		return nil, err
+	}
+	if !amount.IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+
+	reference, err := rs.authorize(amount, method)
+	if err != nil {
+		return nil, err
+	}
+
+	now := rs.now()
+	payment := &models.Payment{
+		ReservationID:     reservationID,
+		Amount:            amount,
+		RefundedAmount:    money.New(0, amount.Currency),
+		Method:            method,
+		ProviderReference: reference,
+		Status:            models.PaymentAuthorized,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if err := rs.repo.SavePayment(payment); err != nil {
+		rs.gateway.Void(reference)
+		return nil, err
+	}
+	return payment, nil
+}
+
+// CapturePayment takes the money held by an authorized payment.
+func (rs *RentalSystem) CapturePayment(paymentID int) (*models.Payment, error) {
+	rs.mu.Lock()
+	defer rs.unlock()
+
+	payment, err := findPayment(rs.repo, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	if payment.Status != models.PaymentAuthorized {
+		return nil, ErrInvalidPaymentState
+	}
+
+	if err := rs.gateway.Capture(payment.ProviderReference, payment.Amount); err != nil {
+		return nil, err
+	}
+	payment.Status = models.PaymentCaptured
+	payment.UpdatedAt = rs.now()
+	if err := rs.applyCapture(payment); err != nil {
+		// As in ProcessPayment, the money goes back rather than stay
+		// taken without a record; the authorization is used up either way.
+		rs.gateway.Refund(payment.ProviderReference, payment.Amount)
+		return nil, err
+	}
+	return payment, nil
+}
+
+// VoidPayment releases an authorization that will not be captured.
+func (rs *RentalSystem) VoidPayment(paymentID int) (*models.Payment, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	payment, err := findPayment(rs.repo, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := rs.void(rs.repo, payment); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+func (rs *RentalSystem) void(repo repository.Repository, payment *models.Payment) error {
+	if payment.Status != models.PaymentAuthorized {
+		return ErrInvalidPaymentState
+	}
+	if err := rs.gateway.Void(payment.ProviderReference); err != nil {
+		return err
+	}
+	payment.Status = models.PaymentVoided
+	payment.UpdatedAt = rs.now()
+	return repo.SavePayment(payment)
+}
+
+// RefundPayment returns amount of a captured payment to the customer. An
+// amount of zero or less refunds everything still held.
+func (rs *RentalSystem) RefundPayment(paymentID int, amount money.Money) (*models.Payment, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	payment, err := findPayment(rs.repo, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := rs.refund(payment, amount); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// refund gives back part of a captured payment and lowers the amount paid
+// on its reservation, if the reservation still exists. The provider is
+// called outside any transaction, so a refund it has made is never rolled
+// back in the records.
+func (rs *RentalSystem) refund(payment *models.Payment, amount money.Money) error {
+	captured := payment.Captured()
+	if !captured.IsPositive() {
+		return ErrInvalidPaymentState
+	}
+	amount, err := paymentAmount(amount, captured)
+	if err != nil {
+		return err
+	}
+
+	if err := rs.gateway.Refund(payment.ProviderReference, amount); err != nil {
+		return err
+	}
+	return rs.repo.Transaction(func(tx repository.Repository) error {
+		return rs.recordRefund(tx, payment, amount)
+	})
+}
+
+// recordRefund books a refund the provider has made against the payment
+// and its reservation.
+func (rs *RentalSystem) recordRefund(repo repository.Repository, payment *models.Payment, amount money.Money) error {
+	refunded, err := payment.RefundedAmount.CheckedAdd(amount)
+	if err != nil {
+		return err
+	}
+	payment.RefundedAmount = refunded
+	payment.Status = models.PaymentPartiallyRefunded
+	if payment.RefundedAmount.Cmp(payment.Amount) >= 0 {
+		payment.Status = models.PaymentRefunded
+	}
+	payment.UpdatedAt = rs.now()
+	if err := repo.SavePayment(payment); err != nil {
+		return err
+	}
+
+	res, err := findReservation(repo, payment.ReservationID)
+	if errors.Is(err, ErrReservationNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if res.AmountPaid, err = res.AmountPaid.CheckedSub(amount); err != nil {
+		return err
+	}
+	if err := settleBalance(res); err != nil {
+		return err
+	}
+	return repo.SaveReservation(res)
+}
+
+// voidAuthorizations releases every hold on a reservation that was never
+// captured. Each hold is recorded as voided as soon as the provider has
+// released it; one the provider refuses to release stays authorized and
+// the rest are still tried.
+func (rs *RentalSystem) voidAuthorizations(reservationID int) error {
+	all, err := rs.repo.PaymentsForReservation(reservationID)
+	if err != nil {
+		return err
+	}
+	var failed error
+	for i := range all {
+		if all[i].Status == models.PaymentAuthorized {
+			if err := rs.void(rs.repo, &all[i]); err != nil && failed == nil {
+				failed = err
+			}
+		}
+	}
+	return failed
+}
+
+// refundReservation gives amount back across the reservation's captured
+// payments, newest first. Every refund is recorded once the provider has
+// made it, so when one fails the ones before it stand and the rest stays
+// due on the reservation.
+func (rs *RentalSystem) refundReservation(reservationID int, amount money.Money) error {
+	all, err := rs.repo.PaymentsForReservation(reservationID)
+	if err != nil {
+		return err
+	}
+	for i := len(all) - 1; i >= 0 && amount.IsPositive(); i-- {
+		captured := all[i].Captured()
+		if !captured.IsPositive() {
+			continue
+		}
+		part := money.Min(captured, amount)
+		if err := rs.refund(&all[i], part); err != nil {
+			return err
+		}
+		amount = amount.Sub(part)
+	}
+	return nil
+}
+
+func (rs *RentalSystem) Payments(reservationID int) ([]models.Payment, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if _, err := findReservation(rs.repo, reservationID); err != nil {
+		return nil, err
+	}
+	return rs.repo.PaymentsForReservation(reservationID)
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"car-rental-system/money"
+	"car-rental-system/payments"
+	models "car-rental-system/rental_system_models"
+	"car-rental-system/repository"
+	"errors"
+	"testing"
+)
+
+var errStoreDown = errors.New("store down")
+
+// failingRepository fails every transaction once failing is set.
+type failingRepository struct {
+	repository.Repository
+	failing bool
+}
+
+func (r *failingRepository) Transaction(fn func(tx repository.Repository) error) error {
+	if r.failing {
+		return errStoreDown
+	}
+	return r.Repository.Transaction(fn)
+}
+
+// refundingGateway records the refunds made through it.
+type refundingGateway struct {
+	*payments.FakeGateway
+	refunded map[string]money.Money
+}
+
+func (g *refundingGateway) Refund(reference string, amount money.Money) error {
+	if err := g.FakeGateway.Refund(reference, amount); err != nil {
+		return err
+	}
+	g.refunded[reference] = amount
+	return nil
+}
+
+func TestCapturePaymentRefundsWhenNotRecorded(t *testing.T) {
+	repo := &failingRepository{Repository: repository.NewMemoryRepository()}
+	gateway := &refundingGateway{FakeGateway: payments.NewFakeGateway(), refunded: map[string]money.Money{}}
+	rs, customer := newTestSystemWithRepository(t, repo, WithPaymentGateway(gateway))
+
+	res, err := rs.CreateReservation(ReservationRequest{CustomerID: customer.ID, CarID: 1, StartDate: "2025-03-10", EndDate: "2025-03-12"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	payment, err := rs.AuthorizePayment(res.ID, res.AmountDue, "card")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo.failing = true
+	if _, err := rs.CapturePayment(payment.ID); !errors.Is(err, errStoreDown) {
+		t.Fatalf("CapturePayment: got %v, want %v", err, errStoreDown)
+	}
+	if got := gateway.refunded[payment.ProviderReference]; got != payment.Amount {
+		t.Errorf("refunded %v, want the captured %v", got, payment.Amount)
+	}
+
+	repo.failing = false
+	stored, err := rs.GetReservation(res.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.AmountPaid.IsPositive() {
+		t.Errorf("amount paid = %v, want nothing recorded", stored.AmountPaid)
+	}
+	if p, err := rs.repo.Payment(payment.ID); err != nil || p.Status != models.PaymentAuthorized {
+		t.Errorf("payment = %+v, %v; want it left authorized", p, err)
+	}
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	models "car-rental-system/rental_system_models"
+	"car-rental-system/repository"
+	"errors"
+	"slices"
+	"strings"
+)
+
+func findPromoCode(repo repository.Repository, code string) (*models.PromoCode, error) {
+	promo, err := repo.PromoCode(code)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrPromoNotFound
+	}
+	return promo, err
+}
+
+func normalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func validatePromoCode(promo *models.PromoCode) error {
+	promo.Code = normalizePromoCode(promo.Code)
+	if promo.Code == "" || promo.MinDays < 0 || promo.MaxUses < 0 || promo.MaxUsesPerCustomer < 0 {
+		return ErrInvalidPromo
+	}
+	switch promo.Kind {
+	case models.DiscountPercent:
+		if promo.Value <= 0 || promo.Value > 100 || !promo.Amount.IsZero() {
+			return ErrInvalidPromo
+		}
+	case models.DiscountFixed:
+		if !promo.Amount.IsPositive() || promo.Value != 0 {
+			return ErrInvalidPromo
+		}
+	default:
+		return ErrInvalidPromo
+	}
+	if !promo.ValidFrom.IsZero() && !promo.ValidUntil.IsZero() && !promo.ValidFrom.Before(promo.ValidUntil) {
+		return ErrInvalidPromo
+	}
+	for i, name := range promo.Makes {
+		if promo.Makes[i] = strings.TrimSpace(name); promo.Makes[i] == "" {
+			return ErrInvalidPromo
+		}
+	}
+	for i, class := range promo.Classes {
+		if promo.Classes[i] = normalizeClassCode(class); promo.Classes[i] == "" {
+			return ErrInvalidPromo
+		}
+	}
+	return nil
+}
+
+// AddPromoCode adds a campaign code customers can book with.
+func (rs *RentalSystem) AddPromoCode(promo models.PromoCode) (*models.PromoCode, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if err := validatePromoCode(&promo); err != nil {
+		return nil, err
+	}
+	if promo.Kind == models.DiscountFixed {
+		if err := rs.normalizeAmount(&promo.Amount, ""); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := rs.repo.PromoCode(promo.Code); err == nil {
+		return nil, ErrPromoExists
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	for _, class := range promo.Classes {
+		if _, err := findVehicleClass(rs.repo, class); err != nil {
+			return nil, err
+		}
+	}
+	if err := rs.repo.SavePromoCode(&promo); err != nil {
+		return nil, err
+	}
+	return &promo, nil
+}
+
+func (rs *RentalSystem) GetPromoCode(code string) (*models.PromoCode, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return findPromoCode(rs.repo, normalizePromoCode(code))
+}
+
+// ListPromoCodes returns every promo code ordered by code.
+func (rs *RentalSystem) ListPromoCodes() ([]models.PromoCode, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return rs.repo.PromoCodes()
+}
+
+// applyPromo checks that the promo code can be used on a reservation about
+// to be booked and attaches it for pricing. The use itself is recorded by
+// recordPromoUse when the reservation is saved.
+func (rs *RentalSystem) applyPromo(code string, res *models.Reservation, car *models.Car, points int) error {
+	promo, err := findPromoCode(rs.repo, normalizePromoCode(code))
+	if err != nil {
+		return err
+	}
+	now := rs.now()
+	if (!promo.ValidFrom.IsZero() && now.Before(promo.ValidFrom)) || (!promo.ValidUntil.IsZero() && !now.Before(promo.ValidUntil)) {
+		return ErrPromoNotValid
+	}
+	if rs.dayPolicy.BillableDays(res.StartDate, res.EndDate) < promo.MinDays {
+		return ErrPromoNotApplicable
+	}
+	if len(promo.Classes) > 0 && !slices.Contains(promo.Classes, res.VehicleClass) {
+		return ErrPromoNotApplicable
+	}
+	// A class booking does not know its make until a car is assigned.
+	if len(promo.Makes) > 0 && (res.ClassOnly || !slices.ContainsFunc(promo.Makes, func(name string) bool {
+		return strings.EqualFold(name, car.Make)
+	})) {
+		return ErrPromoNotApplicable
+	}
+	if promo.NonStackable && points > 0 {
+		return ErrPromoNotStackable
+	}
+	if err := checkPromoUses(rs.repo, promo, res.CustomerID); err != nil {
+		return err
+	}
+
+	res.Promo = &models.AppliedPromo{
+		Code:         promo.Code,
+		Kind:         promo.Kind,
+		Value:        promo.Value,
+		Amount:       promo.Amount,
+		MinDays:      promo.MinDays,
+		NonStackable: promo.NonStackable,
+	}
+	return nil
+}
+
+// checkPromoUses makes sure neither the code's usage limit nor the
+// customer's has been reached. Voided uses do not count.
+func checkPromoUses(repo repository.Repository, promo *models.PromoCode, customerID int) error {
+	if promo.MaxUses == 0 && promo.MaxUsesPerCustomer == 0 {
+		return nil
+	}
+	redemptions, err := repo.PromoRedemptions(promo.Code)
+	if err != nil {
+		return err
+	}
+	uses, customerUses := 0, 0
+	for _, redemption := range redemptions {
+		if redemption.Voided {
+			continue
+		}
+		uses++
+		if redemption.CustomerID == customerID {
+			customerUses++
+		}
+	}
+	if (promo.MaxUses > 0 && uses >= promo.MaxUses) || (promo.MaxUsesPerCustomer > 0 && customerUses >= promo.MaxUsesPerCustomer) {
+		return ErrPromoUsedUp
+	}
+	return nil
+}
+
+// recordPromoUse records the use of a new reservation's promo code. The
+// code is locked and its limits checked again inside the booking's
+// transaction, so bookings made at the same time, in this process or in
+// another sharing the database, cannot take it past them.
+func (rs *RentalSystem) recordPromoUse(tx repository.Repository, res *models.Reservation) error {
+	if res.Promo == nil {
+		return nil
+	}
+	promo, err := tx.LockPromoCode(res.Promo.Code)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrPromoNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if err := checkPromoUses(tx, promo, res.CustomerID); err != nil {
+		return err
+	}
+	return tx.SavePromoRedemption(&models.PromoRedemption{
+		Code:          promo.Code,
+		CustomerID:    res.CustomerID,
+		ReservationID: res.ID,
+		At:            rs.now(),
+	})
+}
+
+// voidPromoUse gives back the use of the promo code a cancelled reservation
+// was booked with.
+func (rs *RentalSystem) voidPromoUse(tx repository.Repository, res *models.Reservation) error {
+	if res.Promo == nil {
+		return nil
+	}
+	redemptions, err := tx.PromoRedemptions(res.Promo.Code)
+	if err != nil {
+		return err
+	}
+	for i := range redemptions {
+		redemption := &redemptions[i]
+		if redemption.ReservationID != res.ID || redemption.Voided {
+			continue
+		}
+		redemption.Voided = true
+		if err := tx.SavePromoRedemption(redemption); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	models "car-rental-system/rental_system_models"
+	"car-rental-system/repository"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPromoCodeUsageLimits(t *testing.T) {
+	type booking struct {
+		// other books as the second customer.
+		other bool
+		// cancel cancels the booking made just before instead.
+		cancel  bool
+		wantErr error
+	}
+	tests := []struct {
+		name     string
+		maxUses  int
+		perUser  int
+		bookings []booking
+	}{
+		{"total limit", 2, 0, []booking{{}, {other: true}, {wantErr: ErrPromoUsedUp}, {other: true, wantErr: ErrPromoUsedUp}}},
+		{"limit per customer", 0, 1, []booking{{}, {wantErr: ErrPromoUsedUp}, {other: true}, {other: true, wantErr: ErrPromoUsedUp}}},
+		{"cancelling gives the use back", 1, 0, []booking{{}, {other: true, wantErr: ErrPromoUsedUp}, {cancel: true}, {other: true}}},
+		{"cancelling gives the customer's use back", 0, 1, []booking{{}, {cancel: true}, {}, {wantErr: ErrPromoUsedUp}}},
+	}
+	backends := []struct {
+		name string
+		open func(t *testing.T) repository.Repository
+	}{
+		{"memory", func(t *testing.T) repository.Repository { return repository.NewMemoryRepository() }},
+		{"sqlite", func(t *testing.T) repository.Repository {
+			repo, err := repository.OpenSQLite(filepath.Join(t.TempDir(), "rentals.db"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return repo
+		}},
+	}
+	for _, backend := range backends {
+		for _, tt := range tests {
+			t.Run(backend.name+"/"+tt.name, func(t *testing.T) {
+				rs, customer := newTestSystemWithRepository(t, backend.open(t))
+				other, err := rs.RegisterCustomer(models.Customer{
+					Name:           "Bo Chen",
+					Email:          "bo@example.com",
+					DateOfBirth:    time.Date(1985, time.June, 5, 0, 0, 0, 0, time.UTC),
+					DriversLicense: "D1234567",
+					LicenseRegion:  "US-CA",
+					LicenseExpiry:  time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, err := rs.AddPromoCode(models.PromoCode{Code: "spring10", Kind: models.DiscountPercent, Value: 10, MaxUses: tt.maxUses, MaxUsesPerCustomer: tt.perUser}); err != nil {
+					t.Fatal(err)
+				}
+
+				var last *models.Reservation
+				start := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+				for i, b := range tt.bookings {
+					if b.cancel {
+						if _, err := rs.CancelReservation(last.ID); err != nil {
+							t.Fatalf("booking %d: cancel: %v", i, err)
+						}
+						continue
+					}
+					who := customer.ID
+					if b.other {
+						who = other.ID
+					}
+					// Every booking gets days of its own on car 1.
+					from := start.AddDate(0, 0, 2*i)
+					res, err := rs.CreateReservation(ReservationRequest{
+						CustomerID: who,
+						CarID:      1,
+						StartDate:  from.Format(time.DateOnly),
+						EndDate:    from.AddDate(0, 0, 2).Format(time.DateOnly),
+						PromoCode:  "SPRING10",
+					})
+					if !errors.Is(err, b.wantErr) {
+						t.Fatalf("booking %d: got %v, want %v", i, err, b.wantErr)
+					}
+					if err == nil {
+						if res.Promo == nil || res.Promo.Code != "SPRING10" {
+							t.Fatalf("booking %d: promo = %+v, want SPRING10", i, res.Promo)
+						}
+						last = res
+					}
+				}
+			})
+		}
+	}
+}
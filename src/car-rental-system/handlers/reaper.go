@@ -0,0 +1,54 @@
+package services
+
+import "time"
+
+// ReapResult counts what one pass of the reaper expired.
+type ReapResult struct {
+	Holds          int `json:"holds"`
+	WaitlistOffers int `json:"waitlistOffers"`
+}
+
+// Reap expires the holds and waitlist offers that have run out, frees what
+// they kept and offers it to the waitlist.
+func (rs *RentalSystem) Reap() (ReapResult, error) {
+	rs.mu.Lock()
+	defer rs.unlock()
+
+	var result ReapResult
+	var err error
+	if result.Holds, err = rs.expireHolds(); err != nil {
+		return result, err
+	}
+	if result.WaitlistOffers, err = rs.expireWaitlistOffers(); err != nil {
+		return result, err
+	}
+	rs.offerWaitlist()
+	return result, nil
+}
+
+// StartReaper runs Reap every interval in the background until the
+// returned function is called. Errors are passed to onError, which may be
+// nil; the next pass tries again.
+func (rs *RentalSystem) StartReaper(interval time.Duration, onError func(error)) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := rs.Reap(); err != nil && onError != nil {
+					onError(err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		ticker.Stop()
+		close(done)
+		<-finished
+	}
+}
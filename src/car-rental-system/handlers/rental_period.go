@@ -0,0 +1,75 @@
+package services
+
+import "time"
+
+const day = 24 * time.Hour
+
+// Dates may be given as plain days ("2025-03-29") or with a time of day
+// when the pickup and return times matter for billing.
+var dateLayouts = []string{
+	"2006-01-02",
+	"2006-01-02T15:04",
+	time.RFC3339,
+}
+
+// startOfDay returns midnight UTC at the start of t's day.
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func parseDate(value string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, ErrInvalidDate
+}
+
+// RentalDayPolicy decides how many days a rental period is billed for.
+type RentalDayPolicy struct {
+	// GracePeriod is how long a rental may run past a full day before
+	// another day is charged.
+	GracePeriod time.Duration
+	// MinimumDays is the smallest number of days ever billed.
+	MinimumDays int
+}
+
+func DefaultRentalDayPolicy() RentalDayPolicy {
+	return RentalDayPolicy{GracePeriod: time.Hour, MinimumDays: 1}
+}
+
+// BillableDays returns the number of days charged for a rental between
+// start and end.
+func (p RentalDayPolicy) BillableDays(start, end time.Time) int {
+	length := end.Sub(start)
+	days := int(length / day)
+	if length%day > p.GracePeriod {
+		days++
+	}
+	if days < p.MinimumDays {
+		days = p.MinimumDays
+	}
+	return days
+}
+
+// parseRentalPeriod parses and validates the dates of a booking request.
+func (rs *RentalSystem) parseRentalPeriod(startDate, endDate string) (time.Time, time.Time, error) {
+	start, err := parseDate(startDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end, err := parseDate(endDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if !end.After(start) {
+		return time.Time{}, time.Time{}, ErrEndBeforeStart
+	}
+
+	if start.Before(startOfDay(rs.now())) {
+		return time.Time{}, time.Time{}, ErrDateInPast
+	}
+	return start, end, nil
+}
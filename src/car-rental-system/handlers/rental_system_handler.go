@@ -1,137 +1,686 @@
 package services
 
 import (
+	"car-rental-system/eligibility"
+	"car-rental-system/events"
+	"car-rental-system/money"
+	"car-rental-system/payments"
+	"car-rental-system/pricing"
 	models "car-rental-system/rental_system_models"
+	"car-rental-system/repository"
 	"errors"
-	"fmt"
+	"slices"
 	"sync"
+	"time"
 )
 
 type RentalSystem struct {
-	cars          map[int]*models.Car
-	reservations  map[int]*models.Reservation
-	mu            sync.Mutex
-	reservationID int
+	repo      repository.Repository
+	mu        sync.Mutex
+	calendar  *availabilityCalendar
+	dayPolicy RentalDayPolicy
+	pricing   *pricing.Engine
+	gateway   payments.Gateway
+	// eligibility decides who may drive which car; the blocklist is
+	// checked separately, from the repository.
+	eligibility *eligibility.Checker
+	// cancellationPolicy applies to cars without a policy of their own.
+	cancellationPolicy models.CancellationPolicy
+	// usagePolicy prices late returns, mileage and fuel at check-in. Left
+	// unset, the default is built in the base currency.
+	usagePolicy *UsagePolicy
+	// loyaltyPolicy awards and values points and sets the tiers. Left
+	// unset, the default is built in the base currency.
+	loyaltyPolicy *LoyaltyPolicy
+	// currencyPolicy sets the base currency and how amounts are converted.
+	currencyPolicy CurrencyPolicy
+	// taxRates are the taxes included in every price, shown on invoices.
+	taxRates []models.TaxRate
+	// waitlistHold is how long a waitlisted customer has to take up an
+	// offer before it passes to the next one.
+	waitlistHold time.Duration
+	// holdDuration is how long PlaceHold keeps a car.
+	holdDuration time.Duration
+	now          func() time.Time
+	// events receives the domain events; pending holds the ones raised
+	// under mu until it is released.
+	events  *events.Bus
+	pending []events.Event
+	// outbox queues the events of released changes in the order they were
+	// made; draining is set while one goroutine publishes them.
+	outboxMu sync.Mutex
+	outbox   []events.Event
+	draining bool
 }
 
-func NewRentalSystem() *RentalSystem {
-	return &RentalSystem{
-		cars:         make(map[int]*models.Car),
-		reservations: make(map[int]*models.Reservation),
+// Option configures a RentalSystem created by NewRentalSystem.
+type Option func(*RentalSystem)
+
+// WithRentalDayPolicy overrides the default partial-day billing rules.
+func WithRentalDayPolicy(policy RentalDayPolicy) Option {
+	return func(rs *RentalSystem) {
+		rs.dayPolicy = policy
 	}
 }
 
-func (rs *RentalSystem) AddCar(car models.Car) {
-	rs.mu.Lock()
-	defer rs.mu.Unlock()
-	rs.cars[car.ID] = &car
+// WithUsagePolicy sets what is charged at check-in for late returns,
+// excess mileage, refuelling and returns to another branch than booked.
+// Its amounts must be in the base currency; those without a currency are
+// taken to be.
+func WithUsagePolicy(policy UsagePolicy) Option {
+	return func(rs *RentalSystem) {
+		rs.usagePolicy = &policy
+	}
+}
+
+// WithLoyaltyPolicy sets how loyalty points are earned and redeemed and
+// what each tier takes. Its amounts must be in the base currency; those
+// without a currency are taken to be.
+func WithLoyaltyPolicy(policy LoyaltyPolicy) Option {
+	policy.Tiers = slices.Clone(policy.Tiers)
+	return func(rs *RentalSystem) {
+		rs.loyaltyPolicy = &policy
+	}
+}
+
+// WithCurrencyPolicy sets the base currency and when and how amounts in
+// other currencies are converted.
+func WithCurrencyPolicy(policy CurrencyPolicy) Option {
+	return func(rs *RentalSystem) {
+		rs.currencyPolicy = policy
+	}
+}
+
+// WithPricingEngine sets the rules used to price reservations.
+func WithPricingEngine(engine *pricing.Engine) Option {
+	return func(rs *RentalSystem) {
+		rs.pricing = engine
+	}
+}
+
+// WithEligibilityChecker sets the checks drivers must pass before a car is
+// booked for them.
+func WithEligibilityChecker(checker *eligibility.Checker) Option {
+	return func(rs *RentalSystem) {
+		rs.eligibility = checker
+	}
+}
+
+// WithPaymentGateway sets the provider payments go through. The default is
+// the fake gateway, which never talks to a real provider.
+func WithPaymentGateway(gateway payments.Gateway) Option {
+	return func(rs *RentalSystem) {
+		rs.gateway = gateway
+	}
+}
+
+// WithCancellationPolicy sets the default policy for cars that do not
+// have their own.
+func WithCancellationPolicy(policy models.CancellationPolicy) Option {
+	return func(rs *RentalSystem) {
+		rs.cancellationPolicy = policy
+	}
+}
+
+// WithTaxRates sets the taxes that prices include, so invoices can show
+// them separately.
+func WithTaxRates(rates ...models.TaxRate) Option {
+	return func(rs *RentalSystem) {
+		rs.taxRates = rates
+	}
+}
+
+// WithWaitlistHold sets how long a car or class stays held for a
+// waitlisted customer once it has been offered to them.
+func WithWaitlistHold(d time.Duration) Option {
+	return func(rs *RentalSystem) {
+		rs.waitlistHold = d
+	}
+}
+
+// WithHoldDuration sets how long a hold keeps its car before it expires.
+func WithHoldDuration(d time.Duration) Option {
+	return func(rs *RentalSystem) {
+		rs.holdDuration = d
+	}
+}
+
+// WithEventBus publishes the system's domain events on bus, in the order
+// the changes were made. A call's events have been delivered when it
+// returns unless other calls are delivering theirs at the same time, or it
+// was made by a subscriber; then they follow after those, in order.
+func WithEventBus(bus *events.Bus) Option {
+	return func(rs *RentalSystem) {
+		rs.events = bus
+	}
+}
+
+// WithClock replaces time.Now, mainly so bookings can be made against a
+// fixed date.
+func WithClock(now func() time.Time) Option {
+	return func(rs *RentalSystem) {
+		rs.now = now
+	}
+}
+
+// NewRentalSystem returns a rental system that keeps its data in memory.
+// It panics when the options are invalid.
+func NewRentalSystem(opts ...Option) *RentalSystem {
+	rs, err := NewRentalSystemWithRepository(repository.NewMemoryRepository(), opts...)
+	if err != nil {
+		panic(err)
+	}
+	return rs
 }
 
-func (rs *RentalSystem) SearchCars(make string, maxPrice float64) []models.Car {
+// NewRentalSystemWithRepository returns a rental system backed by repo and
+// rebuilds the availability calendar from the reservations, holds,
+// maintenance windows and open waitlist offers already stored.
+func NewRentalSystemWithRepository(repo repository.Repository, opts ...Option) (*RentalSystem, error) {
+	rs := &RentalSystem{
+		repo:           repo,
+		calendar:       newAvailabilityCalendar(),
+		dayPolicy:      DefaultRentalDayPolicy(),
+		currencyPolicy: DefaultCurrencyPolicy(),
+		pricing:        pricing.DefaultEngine(),
+		gateway:        payments.NewFakeGateway(),
+		eligibility:    eligibility.DefaultChecker(),
+		waitlistHold:   defaultWaitlistHold,
+		holdDuration:   defaultHoldDuration,
+		now:            time.Now,
+	}
+	for _, opt := range opts {
+		opt(rs)
+	}
+	base, err := money.ParseCurrency(string(rs.currencyPolicy.Base))
+	if err != nil {
+		return nil, err
+	}
+	rs.currencyPolicy.Base = base
+	if rs.loyaltyPolicy == nil {
+		policy := DefaultLoyaltyPolicy(base)
+		rs.loyaltyPolicy = &policy
+	}
+	if err := rs.loyaltyPolicy.validate(base); err != nil {
+		return nil, err
+	}
+	if rs.usagePolicy == nil {
+		policy := DefaultUsagePolicy(base)
+		rs.usagePolicy = &policy
+	}
+	if err := rs.usagePolicy.validate(base); err != nil {
+		return nil, err
+	}
+
+	reservations, err := repo.Reservations()
+	if err != nil {
+		return nil, err
+	}
+	for _, res := range reservations {
+		// Returned cars are free again, whatever the booked dates say.
+		if res.Status == models.ReservationCancelled || res.Status == models.ReservationCompleted || res.CarID == 0 {
+			continue
+		}
+		rs.calendar.book(&res)
+	}
+
+	holds, err := repo.Holds()
+	if err != nil {
+		return nil, err
+	}
+	for _, hold := range holds {
+		if hold.Status == models.HoldActive {
+			rs.calendar.hold(&hold)
+		}
+	}
+
+	windows, err := repo.MaintenanceWindows()
+	if err != nil {
+		return nil, err
+	}
+	for _, window := range windows {
+		rs.calendar.block(window.CarID, window.ID, window.Start, window.End)
+	}
+
+	waitlist, err := repo.WaitlistEntries()
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range waitlist {
+		if entry.Status == models.WaitlistOffered {
+			rs.calendar.holdOffer(&entry)
+		}
+	}
+	return rs, nil
+}
+
+func findCar(repo repository.Repository, carID int) (*models.Car, error) {
+	car, err := repo.Car(carID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrCarNotFound
+	}
+	return car, err
+}
+
+func findReservation(repo repository.Repository, reservationID int) (*models.Reservation, error) {
+	res, err := repo.Reservation(reservationID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrReservationNotFound
+	}
+	return res, err
+}
+
+func (rs *RentalSystem) AddCar(car models.Car) error {
 	rs.mu.Lock()
-	defer rs.mu.Unlock()
+	defer rs.unlock()
 
-	var results []models.Car
-	for _, car := range rs.cars {
-		if car.Make == make && car.RentalPricePerDay <= maxPrice && car.IsAvailable {
-			results = append(results, *car)
+	if _, err := rs.repo.Car(car.ID); err == nil {
+		return ErrCarAlreadyExists
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	if car.Status == "" {
+		car.Status = models.CarActive
+	}
+	if !car.Status.Valid() {
+		return ErrInvalidCarStatus
+	}
+	if car.Class != "" {
+		car.Class = normalizeClassCode(car.Class)
+		if _, err := findVehicleClass(rs.repo, car.Class); err != nil {
+			return err
 		}
 	}
-	return results
+	// Prices without a currency are in the one of the car's branch.
+	var currency money.Currency
+	if branch, err := rs.repo.Branch(car.BranchID); err == nil {
+		currency = branch.Currency
+	}
+	if err := rs.normalizeAmount(&car.RentalPricePerDay, currency); err != nil {
+		return err
+	}
+	if err := rs.repo.SaveCar(&car); err != nil {
+		return err
+	}
+	rs.publish(events.CarAdded{Car: car, At: rs.now()})
+	// A new car adds to its class.
+	rs.offerWaitlist()
+	return nil
 }
 
-func (rs *RentalSystem) CreateReservation(customer models.Customer, carID int, startDate, endDate string) (*models.Reservation, error) {
+func (rs *RentalSystem) GetReservation(reservationID int) (*models.Reservation, error) {
 	rs.mu.Lock()
 	defer rs.mu.Unlock()
+	return findReservation(rs.repo, reservationID)
+}
 
-	car, exists := rs.cars[carID]
-	if !exists || !car.IsAvailable {
-		return nil, errors.New("car not available")
+func (rs *RentalSystem) ListCars() ([]models.Car, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return rs.repo.Cars()
+}
+
+// ReservationRequest asks for a car over [StartDate, EndDate). Either CarID
+// names the car, or it is left at zero and VehicleClass is booked instead.
+// The branches may be left at zero: pickup then defaults to wherever the
+// car will be and drop-off to the pickup branch. RedeemPoints takes up to
+// that many loyalty points off the price. Extras, promo codes and points
+// are only booked with reservations; holds and waitlist entries leave them
+// out.
+type ReservationRequest struct {
+	CustomerID      int
+	CarID           int
+	VehicleClass    string
+	StartDate       string
+	EndDate         string
+	PickupBranchID  int
+	DropoffBranchID int
+	Extras          []ExtraRequest
+	PromoCode       string
+	RedeemPoints    int
+}
+
+func (rs *RentalSystem) CreateReservation(req ReservationRequest) (*models.Reservation, error) {
+	rs.mu.Lock()
+	defer rs.unlock()
+	return rs.reserve(req, nil)
+}
+
+// reserve books req. also, when given, saves whatever else goes with the
+// booking in the same transaction as the reservation.
+func (rs *RentalSystem) reserve(req ReservationRequest, also func(tx repository.Repository, res *models.Reservation) error) (*models.Reservation, error) {
+	start, end, err := rs.parseRentalPeriod(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	customer, err := findCustomer(rs.repo, req.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	if req.CarID == 0 {
+		return rs.createClassReservation(req, customer, start, end, also)
+	}
+
+	car, err := findCar(rs.repo, req.CarID)
+	if errors.Is(err, ErrCarNotFound) {
+		return nil, ErrCarNotAvailable
+	}
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := rs.carBookable(car, start, end, 0); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrCarNotAvailable
+	}
+	pickup, dropoff, err := rs.resolveRoute(car, req.PickupBranchID, req.DropoffBranchID, start, end, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := rs.checkEligibility(customer, car, start, end); err != nil {
+		return nil, err
+	}
+	extras, err := rs.resolveExtras(req.Extras, nil, pickup, start, end, 0)
+	if err != nil {
+		return nil, err
 	}
 
-	rs.reservationID++
 	reservation := &models.Reservation{
-		ID:         rs.reservationID,
-		Customer:   customer,
-		CarID:      carID,
-		StartDate:  startDate,
-		EndDate:    endDate,
-		TotalPrice: car.RentalPricePerDay,
+		Status:             models.ReservationActive,
+		CancellationPolicy: rs.policyFor(car),
+		CustomerID:         customer.ID,
+		CarID:              car.ID,
+		VehicleClass:       car.Class,
+		StartDate:          start,
+		EndDate:            end,
+		PickupBranchID:     pickup,
+		DropoffBranchID:    dropoff,
+		Extras:             extras,
+	}
+	if err := rs.priceNewReservation(reservation, car, customer, req); err != nil {
+		return nil, err
 	}
 
-	rs.reservations[rs.reservationID] = reservation
-	car.IsAvailable = false
+	if err := rs.saveNewReservation(reservation, also); err != nil {
+		return nil, err
+	}
+	rs.calendar.book(reservation)
+	rs.publish(events.ReservationCreated{Reservation: *reservation, At: rs.now()})
 
 	return reservation, nil
 }
 
+// saveNewReservation stores a new reservation, its first invoice, the
+// points it redeemed and the use of its promo code, then runs also in the
+// same transaction.
+func (rs *RentalSystem) saveNewReservation(res *models.Reservation, also func(tx repository.Repository, res *models.Reservation) error) error {
+	return rs.repo.Transaction(func(tx repository.Repository) error {
+		if err := tx.SaveReservation(res); err != nil {
+			return err
+		}
+		if err := rs.bill(tx, res, reasonReservationCreated); err != nil {
+			return err
+		}
+		if err := rs.recordRedemption(tx, res); err != nil {
+			return err
+		}
+		if err := rs.recordPromoUse(tx, res); err != nil {
+			return err
+		}
+		if also != nil {
+			return also(tx, res)
+		}
+		return nil
+	})
+}
+
 func (rs *RentalSystem) ModifyReservation(reservationID int, newStartDate, newEndDate string) error {
 	rs.mu.Lock()
-	defer rs.mu.Unlock()
+	defer rs.unlock()
+
+	res, err := findReservation(rs.repo, reservationID)
+	if err != nil {
+		return err
+	}
+	switch res.Status {
+	case models.ReservationCancelled:
+		return ErrReservationCancelled
+	case models.ReservationInProgress, models.ReservationCompleted:
+		return ErrAlreadyPickedUp
+	}
 
-	res, exists := rs.reservations[reservationID]
-	if !exists {
-		return errors.New("reservation not found")
+	start, end, err := rs.parseRentalPeriod(newStartDate, newEndDate)
+	if err != nil {
+		return err
 	}
 
-	res.StartDate = newStartDate
-	res.EndDate = newEndDate
+	var car, priced *models.Car
+	if res.CarID != 0 {
+		if car, err = findCar(rs.repo, res.CarID); err != nil {
+			return err
+		}
+		// The reservation's own booking must not count as a conflict.
+		if ok, err := rs.carBookable(car, start, end, res.ID); err != nil {
+			return err
+		} else if !ok {
+			return ErrCarNotAvailable
+		}
+		if _, _, err := rs.resolveRoute(car, res.PickupBranchID, res.DropoffBranchID, start, end, res.ID); err != nil {
+			return err
+		}
+		priced = car
+	}
+	if res.ClassOnly {
+		class, err := findVehicleClass(rs.repo, res.VehicleClass)
+		if err != nil {
+			return err
+		}
+		if res.CarID == 0 {
+			if err := rs.checkClassCapacity(class, res.PickupBranchID, res.DropoffBranchID, start, end, res.ID); err != nil {
+				return err
+			}
+			car = classCar(class)
+		}
+		// Class bookings keep the class rate, even after an upgrade.
+		priced = classCar(class)
+	}
+
+	customer, err := findCustomer(rs.repo, res.CustomerID)
+	if err != nil {
+		return err
+	}
+	if err := rs.checkEligibility(customer, car, start, end); err != nil {
+		return err
+	}
+	if err := rs.checkExtraStock(res.Extras, res.PickupBranchID, start, end, res.ID); err != nil {
+		return err
+	}
+	// The promo code stays as booked, but a rental shortened below its
+	// minimum no longer qualifies.
+	if res.Promo != nil && rs.dayPolicy.BillableDays(start, end) < res.Promo.MinDays {
+		return ErrPromoNotApplicable
+	}
+
+	previous := *res
+	res.StartDate = start
+	res.EndDate = end
+	if err := rs.reprice(res, priced, customer); err != nil {
+		return err
+	}
+	err = rs.repo.Transaction(func(tx repository.Repository) error {
+		if err := tx.SaveReservation(res); err != nil {
+			return err
+		}
+		return rs.bill(tx, res, reasonReservationModified)
+	})
+	if err != nil {
+		return err
+	}
+
+	if res.CarID != 0 {
+		rs.calendar.release(res.CarID, res.ID)
+		rs.calendar.book(res)
+	}
+	rs.publish(events.ReservationModified{Reservation: *res, Previous: previous, At: rs.now()})
+	// A shorter or moved rental may have freed what someone is waiting for.
+	rs.offerWaitlist()
 	return nil
 }
 
-func (rs *RentalSystem) ProcessPayment(reservationID int) error {
+// IsCarAvailable reports whether the car is rentable and free for the
+// whole of [startDate, endDate).
+func (rs *RentalSystem) IsCarAvailable(carID int, startDate, endDate string) (bool, error) {
 	rs.mu.Lock()
 	defer rs.mu.Unlock()
 
-	res, exists := rs.reservations[reservationID]
-	if !exists {
-		return errors.New("reservation not found")
+	start, end, err := rs.parseRentalPeriod(startDate, endDate)
+	if err != nil {
+		return false, err
 	}
 
-	if res.Paid {
-		return errors.New("reservation already paid")
+	car, err := findCar(rs.repo, carID)
+	if err != nil {
+		return false, err
 	}
-
-	res.Paid = true
-	fmt.Println("Payment processed for reservation ID:", reservationID)
-	return nil
+	return rs.carBookable(car, start, end, 0)
 }
 
-func (rs *RentalSystem) CancelReservation(reservationID int) error {
+func (rs *RentalSystem) IsCarAvailableOnDate(carID int, date string) (bool, error) {
 	rs.mu.Lock()
 	defer rs.mu.Unlock()
 
-	res, exists := rs.reservations[reservationID]
-	if !exists {
-		return errors.New("reservation not found")
+	target, err := parseDate(date)
+	if err != nil {
+		return false, err
 	}
 
-	if car, exists := rs.cars[res.CarID]; exists {
-		car.IsAvailable = true
+	// Check if the car exists
+	car, err := findCar(rs.repo, carID)
+	if err != nil {
+		return false, err
 	}
 
-	delete(rs.reservations, reservationID)
-	return nil
+	return rs.carBookable(car, target, target.Add(day), 0)
 }
 
-func (rs *RentalSystem) IsCarAvailableOnDate(carID int, date string) (bool, error) {
+// QuoteReservation prices a rental without booking it.
+func (rs *RentalSystem) QuoteReservation(req ReservationRequest) (pricing.Quote, error) {
 	rs.mu.Lock()
 	defer rs.mu.Unlock()
 
-	// Check if the car exists
-	_, exists := rs.cars[carID]
-	if !exists {
-		return false, errors.New("car not found")
+	start, end, err := rs.parseRentalPeriod(req.StartDate, req.EndDate)
+	if err != nil {
+		return pricing.Quote{}, err
+	}
+	customer, err := findCustomer(rs.repo, req.CustomerID)
+	if err != nil {
+		return pricing.Quote{}, err
 	}
+	if req.CarID == 0 {
+		if req.VehicleClass == "" {
+			return pricing.Quote{}, ErrCarOrClassRequired
+		}
+		class, err := findVehicleClass(rs.repo, normalizeClassCode(req.VehicleClass))
+		if err != nil {
+			return pricing.Quote{}, err
+		}
+		dropoff := req.DropoffBranchID
+		if dropoff == 0 {
+			dropoff = req.PickupBranchID
+		}
+		extras, err := rs.resolveExtras(req.Extras, nil, req.PickupBranchID, start, end, 0)
+		if err != nil {
+			return pricing.Quote{}, err
+		}
+		res := &models.Reservation{CustomerID: customer.ID, VehicleClass: class.Code, ClassOnly: true, StartDate: start, EndDate: end, PickupBranchID: req.PickupBranchID, DropoffBranchID: dropoff, Extras: extras}
+		return rs.quoteNewReservation(classCar(class), customer, res, req)
+	}
+	car, err := findCar(rs.repo, req.CarID)
+	if err != nil {
+		return pricing.Quote{}, err
+	}
+	pickup, dropoff, err := rs.resolveRoute(car, req.PickupBranchID, req.DropoffBranchID, start, end, 0)
+	if err != nil {
+		return pricing.Quote{}, err
+	}
+	extras, err := rs.resolveExtras(req.Extras, nil, pickup, start, end, 0)
+	if err != nil {
+		return pricing.Quote{}, err
+	}
+	res := &models.Reservation{CustomerID: customer.ID, CarID: car.ID, VehicleClass: car.Class, StartDate: start, EndDate: end, PickupBranchID: pickup, DropoffBranchID: dropoff, Extras: extras}
+	return rs.quoteNewReservation(car, customer, res, req)
+}
 
-	for _, reservation := range rs.reservations {
-		if reservation.CarID == carID {
-			if date >= reservation.StartDate && date <= reservation.EndDate {
-				fmt.Println("The car is not available.")
-				return false, nil 
-			}
+// quoteNewReservation prices res as booking it with req would, loyalty
+// tier, promo code and points included.
+func (rs *RentalSystem) quoteNewReservation(car *models.Car, customer *models.Customer, res *models.Reservation, req ReservationRequest) (pricing.Quote, error) {
+	if err := rs.priceNewReservation(res, car, customer, req); err != nil {
+		return pricing.Quote{}, err
+	}
+	return pricing.Quote{Lines: res.PriceBreakdown, Total: res.TotalPrice}, nil
+}
+
+// priceNewReservation prices a reservation about to be booked at the
+// customer's loyalty tier, with the promo code and points req asks for.
+func (rs *RentalSystem) priceNewReservation(res *models.Reservation, car *models.Car, customer *models.Customer, req ReservationRequest) error {
+	account, err := rs.loyaltyAccount(rs.repo, customer.ID)
+	if err != nil {
+		return err
+	}
+	res.LoyaltyTier = account.Tier
+	if req.PromoCode != "" {
+		if err := rs.applyPromo(req.PromoCode, res, car, req.RedeemPoints); err != nil {
+			return err
 		}
 	}
+	if err := rs.reprice(res, car, customer); err != nil {
+		return err
+	}
+	return rs.redeemPoints(res, car, customer, account.Points, req.RedeemPoints)
+}
 
-	return true, nil // Car is available
+// reprice sets the reservation's days, price and balance from its dates
+// and branches.
+func (rs *RentalSystem) reprice(res *models.Reservation, car *models.Car, customer *models.Customer) error {
+	rs.setRateDate(res)
+	quote, err := rs.quote(car, *customer, res)
+	if err != nil {
+		return err
+	}
+	res.RentalDays = rs.dayPolicy.BillableDays(res.StartDate, res.EndDate)
+	res.TotalPrice = quote.Total
+	res.PriceBreakdown = quote.Lines
+	return settleBalance(res)
+}
+
+// quote prices the rental of car described by the dates and branches of
+// res, in the currency of the pickup branch and at the rates of the
+// reservation's rate date.
+func (rs *RentalSystem) quote(car *models.Car, customer models.Customer, res *models.Reservation) (pricing.Quote, error) {
+	exchange, err := rs.exchange(rs.repo, res.RateDate)
+	if err != nil {
+		return pricing.Quote{}, err
+	}
+	currency, err := rs.rentalCurrency(car, res)
+	if err != nil {
+		return pricing.Quote{}, err
+	}
+	priced := *car
+	if priced.RentalPricePerDay, err = exchange.Convert(car.RentalPricePerDay, currency); err != nil {
+		return pricing.Quote{}, err
+	}
+	return rs.pricing.Quote(pricing.Request{
+		Car:             priced,
+		Customer:        customer,
+		Start:           res.StartDate,
+		End:             res.EndDate,
+		Days:            rs.dayPolicy.BillableDays(res.StartDate, res.EndDate),
+		PickupBranchID:  res.PickupBranchID,
+		DropoffBranchID: res.DropoffBranchID,
+		Extras:          res.Extras,
+		LoyaltyTier:     res.LoyaltyTier,
+		LoyaltyDiscount: res.LoyaltyDiscount,
+		Promo:           res.Promo,
+		Exchange:        exchange,
+	})
 }
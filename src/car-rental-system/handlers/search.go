@@ -0,0 +1,341 @@
+package services
+
+import (
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SearchSort is the order search results are listed in. Ties are always
+// broken by car ID so paging through results is stable.
+type SearchSort string
+
+const (
+	SortByID         SearchSort = ""
+	SortByPrice      SearchSort = "price"
+	SortByYear       SearchSort = "year"
+	SortByPopularity SearchSort = "popularity"
+)
+
+func (s SearchSort) Valid() bool {
+	switch s {
+	case SortByID, SortByPrice, SortByYear, SortByPopularity:
+		return true
+	}
+	return false
+}
+
+// SearchCriteria filters the fleet. Zero values leave a filter out: an empty
+// make matches every make, a zero MaxPrice means no upper price limit, and
+// so on. Make, Model and Class are matched ignoring case. Prices are
+// compared after converting each car's daily rate into the currency of the
+// price limits at today's rates; limits without a currency are in the base
+// currency, and sorting by price uses it too when no limit is given. Cars
+// whose rate cannot be converted are left out of searches that filter or
+// sort by price.
+//
+// The date window is optional. When it is given only cars free for the
+// whole of [StartDate, EndDate) are listed and BranchID keeps the cars that
+// will be at that branch for pickup; without it BranchID matches where the
+// cars are parked now.
+//
+// Results are paged either by Offset or by Cursor, the NextCursor of the
+// previous page. Cursors keep working when cars are added or booked between
+// pages, offsets may skip or repeat cars. A zero Limit returns everything.
+type SearchCriteria struct {
+	Make      string
+	Model     string
+	MinYear   int
+	MaxYear   int
+	MinPrice  money.Money
+	MaxPrice  money.Money
+	Class     string
+	MinSeats  int
+	BranchID  int
+	StartDate string
+	EndDate   string
+
+	Sort       SearchSort
+	Descending bool
+	Limit      int
+	Offset     int
+	Cursor     string
+}
+
+// SearchResult is one page of search results. Total counts every match,
+// not just the ones on this page, and NextCursor is empty on the last page.
+type SearchResult struct {
+	Cars       []models.Car `json:"cars"`
+	Total      int          `json:"total"`
+	NextCursor string       `json:"nextCursor,omitempty"`
+}
+
+// searchHit is a matching car with the value it is sorted by.
+type searchHit struct {
+	car models.Car
+	key float64
+}
+
+// searchCursor points just past the last car of a page.
+type searchCursor struct {
+	sort       SearchSort
+	descending bool
+	key        float64
+	carID      int
+}
+
+func (c searchCursor) encode() string {
+	raw := fmt.Sprintf("%s|%t|%g|%d", c.sort, c.descending, c.key, c.carID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeSearchCursor(s string) (searchCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return searchCursor{}, ErrInvalidCursor
+	}
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 4 {
+		return searchCursor{}, ErrInvalidCursor
+	}
+	descending, err := strconv.ParseBool(parts[1])
+	if err != nil {
+		return searchCursor{}, ErrInvalidCursor
+	}
+	key, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil {
+		return searchCursor{}, ErrInvalidCursor
+	}
+	carID, err := strconv.Atoi(parts[3])
+	if err != nil {
+		return searchCursor{}, ErrInvalidCursor
+	}
+	return searchCursor{sort: SearchSort(parts[0]), descending: descending, key: key, carID: carID}, nil
+}
+
+// validateSearchCriteria checks the criteria and gives both price limits
+// the same currency, base when neither has one.
+func validateSearchCriteria(criteria *SearchCriteria, base money.Currency) error {
+	if !criteria.Sort.Valid() || criteria.Limit < 0 || criteria.Offset < 0 || criteria.MinSeats < 0 {
+		return ErrInvalidSearch
+	}
+	currency := criteria.MaxPrice.Currency
+	if currency == "" {
+		currency = criteria.MinPrice.Currency
+	}
+	if currency == "" {
+		currency = base
+	}
+	currency, err := money.ParseCurrency(string(currency))
+	if err != nil {
+		return ErrInvalidSearch
+	}
+	for _, price := range []*money.Money{&criteria.MinPrice, &criteria.MaxPrice} {
+		if err := checkCurrency(price, currency); err != nil || price.IsNegative() {
+			return ErrInvalidSearch
+		}
+	}
+	if criteria.MaxPrice.IsPositive() && criteria.MinPrice.Cmp(criteria.MaxPrice) > 0 {
+		return ErrInvalidSearch
+	}
+	if criteria.MaxYear > 0 && criteria.MinYear > criteria.MaxYear {
+		return ErrInvalidSearch
+	}
+	if criteria.Cursor != "" && criteria.Offset > 0 {
+		return ErrInvalidSearch
+	}
+	return nil
+}
+
+// usesPrice reports whether the criteria filter or sort by price.
+func usesPrice(criteria *SearchCriteria) bool {
+	return criteria.MinPrice.IsPositive() || criteria.MaxPrice.IsPositive() || criteria.Sort == SortByPrice
+}
+
+// hasSearchWindow reports whether the criteria ask for availability.
+func hasSearchWindow(criteria *SearchCriteria) bool {
+	return criteria.StartDate != "" || criteria.EndDate != ""
+}
+
+// matchesCar applies the filters that only look at the car itself. price
+// is its daily rate in the currency of the criteria, or zero when the
+// criteria do not look at prices.
+func matchesCar(criteria *SearchCriteria, car *models.Car, price money.Money, seats int) bool {
+	switch {
+	case criteria.Make != "" && !strings.EqualFold(car.Make, strings.TrimSpace(criteria.Make)):
+		return false
+	case criteria.Model != "" && !strings.EqualFold(car.Model, strings.TrimSpace(criteria.Model)):
+		return false
+	case criteria.Class != "" && car.Class != normalizeClassCode(criteria.Class):
+		return false
+	case criteria.MinYear > 0 && car.Year < criteria.MinYear:
+		return false
+	case criteria.MaxYear > 0 && car.Year > criteria.MaxYear:
+		return false
+	case price.Cmp(criteria.MinPrice) < 0:
+		return false
+	case criteria.MaxPrice.IsPositive() && price.Cmp(criteria.MaxPrice) > 0:
+		return false
+	case seats < criteria.MinSeats:
+		return false
+	}
+	return true
+}
+
+// popularity counts the bookings each car has had, cancelled ones aside.
+func (rs *RentalSystem) popularity() (map[int]int, error) {
+	reservations, err := rs.repo.Reservations()
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[int]int)
+	for _, res := range reservations {
+		if res.CarID != 0 && res.Status != models.ReservationCancelled {
+			counts[res.CarID]++
+		}
+	}
+	return counts, nil
+}
+
+// SearchCars lists the rentable cars matching the criteria, sorted and paged
+// as they ask.
+func (rs *RentalSystem) SearchCars(criteria SearchCriteria) (*SearchResult, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if err := validateSearchCriteria(&criteria, rs.currencyPolicy.Base); err != nil {
+		return nil, err
+	}
+	var cursor *searchCursor
+	if criteria.Cursor != "" {
+		c, err := decodeSearchCursor(criteria.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if c.sort != criteria.Sort || c.descending != criteria.Descending {
+			return nil, ErrInvalidCursor
+		}
+		cursor = &c
+	}
+
+	var start, end time.Time
+	if hasSearchWindow(&criteria) {
+		var err error
+		if start, end, err = rs.parseRentalPeriod(criteria.StartDate, criteria.EndDate); err != nil {
+			return nil, err
+		}
+	}
+
+	cars, err := rs.repo.Cars()
+	if err != nil {
+		return nil, err
+	}
+	classes, err := rs.repo.VehicleClasses()
+	if err != nil {
+		return nil, err
+	}
+	seats := make(map[string]int, len(classes))
+	for _, class := range classes {
+		seats[class.Code] = class.Seats
+	}
+	var bookings map[int]int
+	if criteria.Sort == SortByPopularity {
+		if bookings, err = rs.popularity(); err != nil {
+			return nil, err
+		}
+	}
+
+	var exchange money.Exchange
+	if usesPrice(&criteria) {
+		if exchange, err = rs.exchange(rs.repo, rs.now()); err != nil {
+			return nil, err
+		}
+	}
+
+	var loads classLoads
+	if hasSearchWindow(&criteria) {
+		if loads, err = rs.classLoads(0, 0, start, end, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	var hits []searchHit
+	for _, car := range cars {
+		if !car.Rentable() {
+			continue
+		}
+		price := money.New(0, criteria.MaxPrice.Currency)
+		if usesPrice(&criteria) {
+			price, err = exchange.Convert(car.RentalPricePerDay, criteria.MaxPrice.Currency)
+			if errors.Is(err, money.ErrNoRate) {
+				// A car priced in a currency without a rate cannot be
+				// compared, but it does not stop the others being found.
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		if !matchesCar(&criteria, &car, price, seats[car.Class]) {
+			continue
+		}
+		if hasSearchWindow(&criteria) {
+			if !rs.carAvailable(&car, start, end, 0) || !loads.leaveRoomWithout(&car) {
+				continue
+			}
+			if criteria.BranchID != 0 {
+				if _, _, err := rs.resolveRoute(&car, criteria.BranchID, 0, start, end, 0); err != nil {
+					continue
+				}
+			}
+		} else if criteria.BranchID != 0 && car.BranchID != criteria.BranchID {
+			continue
+		}
+
+		hit := searchHit{car: car}
+		switch criteria.Sort {
+		case SortByID:
+			hit.key = float64(car.ID)
+		case SortByPrice:
+			hit.key = float64(price.Amount)
+		case SortByYear:
+			hit.key = float64(car.Year)
+		case SortByPopularity:
+			hit.key = float64(bookings[car.ID])
+		}
+		hits = append(hits, hit)
+	}
+
+	before := func(a searchHit, b searchHit) bool {
+		if a.key != b.key {
+			return (a.key < b.key) != criteria.Descending
+		}
+		return a.car.ID < b.car.ID
+	}
+	sort.Slice(hits, func(i, j int) bool { return before(hits[i], hits[j]) })
+
+	result := &SearchResult{Total: len(hits)}
+	from := min(criteria.Offset, len(hits))
+	if cursor != nil {
+		last := searchHit{car: models.Car{ID: cursor.carID}, key: cursor.key}
+		from = sort.Search(len(hits), func(i int) bool { return before(last, hits[i]) })
+	}
+	to := len(hits)
+	if criteria.Limit > 0 {
+		to = min(from+criteria.Limit, len(hits))
+	}
+	for _, hit := range hits[from:to] {
+		result.Cars = append(result.Cars, hit.car)
+	}
+	if criteria.Limit > 0 && to < len(hits) {
+		last := hits[to-1]
+		result.NextCursor = searchCursor{sort: criteria.Sort, descending: criteria.Descending, key: last.key, carID: last.car.ID}.encode()
+	}
+	return result, nil
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"testing"
+)
+
+func TestSearchCarsWithoutExchangeRate(t *testing.T) {
+	rs, _ := newTestSystem(t)
+	// No rate converts pounds into dollars.
+	if err := rs.AddCar(models.Car{ID: 3, Make: "Mini", Model: "Cooper", Year: 2022, LicensePlate: "LDN123", RentalPricePerDay: money.New(40_00, money.GBP), Class: "economy"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		criteria SearchCriteria
+		want     []int
+	}{
+		{"no price filter", SearchCriteria{}, []int{1, 2, 3}},
+		{"other filters", SearchCriteria{MinYear: 2021}, []int{2, 3}},
+		{"window without price filter", SearchCriteria{StartDate: "2025-03-10", EndDate: "2025-03-12"}, []int{1, 2, 3}},
+		{"maximum price", SearchCriteria{MaxPrice: money.New(100_00, money.USD)}, []int{1, 2}},
+		{"minimum price", SearchCriteria{MinPrice: money.New(55_00, money.USD)}, []int{2}},
+		{"sorted by price", SearchCriteria{Sort: SortByPrice, Descending: true}, []int{2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := rs.SearchCars(tt.criteria)
+			if err != nil {
+				t.Fatalf("SearchCars: %v", err)
+			}
+			var got []int
+			for _, car := range result.Cars {
+				got = append(got, car.ID)
+			}
+			if len(got) != len(tt.want) || result.Total != len(tt.want) {
+				t.Fatalf("found cars %v (total %d), want %v", got, result.Total, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("found cars %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,456 @@
+package services
+
+import (
+	"car-rental-system/events"
+	models "car-rental-system/rental_system_models"
+	"car-rental-system/repository"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ClassAvailability is how many more reservations a vehicle class can take
+// over a period.
+type ClassAvailability struct {
+	Class     models.VehicleClass `json:"class"`
+	Available int                 `json:"available"`
+}
+
+func findVehicleClass(repo repository.Repository, code string) (*models.VehicleClass, error) {
+	class, err := repo.VehicleClass(code)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrVehicleClassNotFound
+	}
+	return class, err
+}
+
+func normalizeClassCode(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
+
+func validateVehicleClass(class *models.VehicleClass) error {
+	class.Code = normalizeClassCode(class.Code)
+	if class.Code == "" || strings.TrimSpace(class.Name) == "" || class.DailyRate.IsNegative() {
+		return ErrInvalidVehicleClass
+	}
+	switch class.Transmission {
+	case "", models.TransmissionManual, models.TransmissionAutomatic:
+	default:
+		return ErrInvalidVehicleClass
+	}
+	switch class.FuelType {
+	case "", models.FuelPetrol, models.FuelDiesel, models.FuelHybrid, models.FuelElectric:
+	default:
+		return ErrInvalidVehicleClass
+	}
+	return nil
+}
+
+// classCar stands in for a car of the class when a class-only reservation
+// is priced or checked, so it is charged at the class rate.
+func classCar(class *models.VehicleClass) *models.Car {
+	return &models.Car{Make: class.Name, Model: "class", Class: class.Code, RentalPricePerDay: class.DailyRate}
+}
+
+func (rs *RentalSystem) AddVehicleClass(class models.VehicleClass) (*models.VehicleClass, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if err := validateVehicleClass(&class); err != nil {
+		return nil, err
+	}
+	if err := rs.normalizeAmount(&class.DailyRate, ""); err != nil {
+		return nil, err
+	}
+	if _, err := rs.repo.VehicleClass(class.Code); err == nil {
+		return nil, ErrVehicleClassExists
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	if err := rs.repo.SaveVehicleClass(&class); err != nil {
+		return nil, err
+	}
+	return &class, nil
+}
+
+// ListVehicleClasses returns the classes from the lowest rank up.
+func (rs *RentalSystem) ListVehicleClasses() ([]models.VehicleClass, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return rs.repo.VehicleClasses()
+}
+
+// AvailableClasses reports, for every class, how many more class-only
+// reservations it can take over [startDate, endDate). A non-zero
+// pickupBranchID only counts the cars that will be at that branch.
+func (rs *RentalSystem) AvailableClasses(startDate, endDate string, pickupBranchID int) ([]ClassAvailability, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	start, end, err := rs.parseRentalPeriod(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	loads, err := rs.classLoads(pickupBranchID, pickupBranchID, start, end, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ClassAvailability, 0, len(loads))
+	for i, load := range loads {
+		result = append(result, ClassAvailability{Class: load.class, Available: loads.spare(i)})
+	}
+	return result, nil
+}
+
+// freeCarsOfClass returns the cars of the class that can be rented over
+// [start, end) between the given branches.
+func (rs *RentalSystem) freeCarsOfClass(code string, pickup, dropoff int, start, end time.Time) ([]models.Car, error) {
+	cars, err := rs.repo.Cars()
+	if err != nil {
+		return nil, err
+	}
+	var free []models.Car
+	for _, car := range cars {
+		if car.Class != code || !rs.carAvailable(&car, start, end, 0) {
+			continue
+		}
+		if _, _, err := rs.resolveRoute(&car, pickup, dropoff, start, end, 0); err != nil {
+			continue
+		}
+		free = append(free, car)
+	}
+	return free, nil
+}
+
+// cheapestFirst sorts cars by their daily rate. Cars priced in different
+// currencies are compared in the base currency at today's rates.
+func (rs *RentalSystem) cheapestFirst(cars []models.Car) error {
+	exchange, err := rs.exchange(rs.repo, rs.now())
+	if err != nil {
+		return err
+	}
+	prices := make(map[int]int64, len(cars))
+	for _, car := range cars {
+		price, err := exchange.Convert(car.RentalPricePerDay, rs.currencyPolicy.Base)
+		if err != nil {
+			return err
+		}
+		prices[car.ID] = price.Amount
+	}
+	sort.SliceStable(cars, func(i, j int) bool { return prices[cars[i].ID] < prices[cars[j].ID] })
+	return nil
+}
+
+// pendingClassReservations counts the class-only reservations of the class
+// that overlap [start, end) and still wait for a car, leaving out ignoreID,
+// together with the places waitlist offers hold in the class.
+func (rs *RentalSystem) pendingClassReservations(code string, start, end time.Time, ignoreID int) (int, error) {
+	reservations, err := rs.repo.Reservations()
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, res := range reservations {
+		if res.ID == ignoreID || res.CarID != 0 || res.Status == models.ReservationCancelled || res.VehicleClass != code {
+			continue
+		}
+		if res.StartDate.Before(end) && start.Before(res.EndDate) {
+			count++
+		}
+	}
+	return count + rs.calendar.classHoldsDuring(code, start, end), nil
+}
+
+// classLoad is a class's free cars over a period together with the
+// class-only reservations and waitlist offers waiting for one of them.
+type classLoad struct {
+	class   models.VehicleClass
+	free    []models.Car
+	pending int
+}
+
+// classLoads holds the load of every class, from the lowest rank up.
+type classLoads []classLoad
+
+// classLoads works out the load of every class over [start, end) for cars
+// that can go between the given branches, leaving out reservation ignoreID.
+func (rs *RentalSystem) classLoads(pickup, dropoff int, start, end time.Time, ignoreID int) (classLoads, error) {
+	classes, err := rs.repo.VehicleClasses()
+	if err != nil {
+		return nil, err
+	}
+	loads := make(classLoads, 0, len(classes))
+	for _, class := range classes {
+		free, err := rs.freeCarsOfClass(class.Code, pickup, dropoff, start, end)
+		if err != nil {
+			return nil, err
+		}
+		pending, err := rs.pendingClassReservations(class.Code, start, end, ignoreID)
+		if err != nil {
+			return nil, err
+		}
+		loads = append(loads, classLoad{class: class, free: free, pending: pending})
+	}
+	return loads, nil
+}
+
+// overbooked reports whether some class-only reservation of a class ranked
+// maxRank or lower can no longer get a car. A reservation takes a car of
+// its own class or of any class ranked above it, so the classes are served
+// from the top rank down, each from its own cars first and then from the
+// cars the classes above have left over. Classes ranked above maxRank that
+// are short already are passed over.
+func (loads classLoads) overbooked(maxRank int) bool {
+	spare := 0
+	for i := len(loads); i > 0; {
+		rank := loads[i-1].class.Rank
+		short, left := 0, 0
+		for ; i > 0 && loads[i-1].class.Rank == rank; i-- {
+			load := loads[i-1]
+			short += max(load.pending-len(load.free), 0)
+			left += max(len(load.free)-load.pending, 0)
+		}
+		if short > spare {
+			if rank <= maxRank {
+				return true
+			}
+			short = spare
+		}
+		spare += left - short
+	}
+	return false
+}
+
+// spare is how many more class-only reservations the class at index i can
+// take without leaving one in its class or below without a car.
+func (loads classLoads) spare(i int) int {
+	trial := append(classLoads(nil), loads...)
+	n := 0
+	for {
+		trial[i].pending++
+		if trial.overbooked(trial[i].class.Rank) {
+			return n
+		}
+		n++
+	}
+}
+
+// leaveRoomWithout reports whether the waiting class-only reservations
+// still all get a car once car is taken out of the free cars of its class.
+func (loads classLoads) leaveRoomWithout(car *models.Car) bool {
+	for i, load := range loads {
+		if load.class.Code != car.Class {
+			continue
+		}
+		for j, free := range load.free {
+			if free.ID != car.ID {
+				continue
+			}
+			trial := append(classLoads(nil), loads...)
+			trial[i].free = append(append([]models.Car(nil), load.free[:j]...), load.free[j+1:]...)
+			return !trial.overbooked(load.class.Rank)
+		}
+	}
+	return true
+}
+
+// upgradePath returns the class followed by the classes ranked above it,
+// in the order upgrades are tried.
+func (rs *RentalSystem) upgradePath(class *models.VehicleClass) ([]models.VehicleClass, error) {
+	classes, err := rs.repo.VehicleClasses()
+	if err != nil {
+		return nil, err
+	}
+	path := []models.VehicleClass{*class}
+	for _, c := range classes {
+		if c.Rank > class.Rank {
+			path = append(path, c)
+		}
+	}
+	return path, nil
+}
+
+// checkClassCapacity accepts a class-only booking when it, and every
+// class-only reservation already waiting in its class or below, can still
+// get a car of its class or of one it could be upgraded to.
+func (rs *RentalSystem) checkClassCapacity(class *models.VehicleClass, pickup, dropoff int, start, end time.Time, ignoreID int) error {
+	loads, err := rs.classLoads(pickup, dropoff, start, end, ignoreID)
+	if err != nil {
+		return err
+	}
+	for i := range loads {
+		if loads[i].class.Code == class.Code && loads.spare(i) > 0 {
+			return nil
+		}
+	}
+	return ErrClassSoldOut
+}
+
+// createClassReservation books a vehicle class without choosing a car; the
+// car is assigned at pickup.
+func (rs *RentalSystem) createClassReservation(req ReservationRequest, customer *models.Customer, start, end time.Time, also func(tx repository.Repository, res *models.Reservation) error) (*models.Reservation, error) {
+	if req.VehicleClass == "" {
+		return nil, ErrCarOrClassRequired
+	}
+	class, err := findVehicleClass(rs.repo, normalizeClassCode(req.VehicleClass))
+	if err != nil {
+		return nil, err
+	}
+
+	pickup, dropoff := req.PickupBranchID, req.DropoffBranchID
+	if dropoff == 0 {
+		dropoff = pickup
+	}
+	if err := rs.checkBranchOpen(pickup, start); err != nil {
+		return nil, err
+	}
+	if err := rs.checkBranchOpen(dropoff, end); err != nil {
+		return nil, err
+	}
+	if err := rs.checkClassCapacity(class, pickup, dropoff, start, end, 0); err != nil {
+		return nil, err
+	}
+	car := classCar(class)
+	if err := rs.checkEligibility(customer, car, start, end); err != nil {
+		return nil, err
+	}
+	extras, err := rs.resolveExtras(req.Extras, nil, pickup, start, end, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	reservation := &models.Reservation{
+		Status:             models.ReservationActive,
+		CancellationPolicy: rs.cancellationPolicy,
+		CustomerID:         customer.ID,
+		VehicleClass:       class.Code,
+		ClassOnly:          true,
+		StartDate:          start,
+		EndDate:            end,
+		PickupBranchID:     pickup,
+		DropoffBranchID:    dropoff,
+		Extras:             extras,
+	}
+	if err := rs.priceNewReservation(reservation, car, customer, req); err != nil {
+		return nil, err
+	}
+	if err := rs.saveNewReservation(reservation, also); err != nil {
+		return nil, err
+	}
+	rs.publish(events.ReservationCreated{Reservation: *reservation, At: rs.now()})
+	return reservation, nil
+}
+
+// AssignCar picks the car for a class-only reservation at pickup. When the
+// booked class has no car left, the next class up that the driver is
+// eligible for is used instead, at no extra charge.
+func (rs *RentalSystem) AssignCar(reservationID int) (*models.Reservation, error) {
+	rs.mu.Lock()
+	defer rs.unlock()
+
+	res, err := findReservation(rs.repo, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	if res.Status == models.ReservationCancelled {
+		return nil, ErrReservationCancelled
+	}
+	if res.CarID != 0 {
+		return nil, ErrCarAlreadyAssigned
+	}
+	if err := rs.assignCar(res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// carAssignment is the car chosen for a class-only reservation and the
+// route it is rented on.
+type carAssignment struct {
+	car             models.Car
+	upgraded        bool
+	pickupBranchID  int
+	dropoffBranchID int
+}
+
+func (a *carAssignment) apply(res *models.Reservation) {
+	res.CarID = a.car.ID
+	res.Upgraded = a.upgraded
+	res.PickupBranchID = a.pickupBranchID
+	res.DropoffBranchID = a.dropoffBranchID
+}
+
+// assignCar gives a class-only reservation its car, as chooseCar picks it,
+// and saves it.
+func (rs *RentalSystem) assignCar(res *models.Reservation) error {
+	assignment, err := rs.chooseCar(res)
+	if err != nil {
+		return err
+	}
+	previous := *res
+	assignment.apply(res)
+	if err := rs.repo.SaveReservation(res); err != nil {
+		return err
+	}
+	rs.calendar.book(res)
+	rs.publish(events.ReservationModified{Reservation: *res, Previous: previous, At: rs.now()})
+	return nil
+}
+
+// chooseCar picks the cheapest free car of the class of a class-only
+// reservation, or of the first class above it the driver may drive,
+// without changing the reservation.
+func (rs *RentalSystem) chooseCar(res *models.Reservation) (*carAssignment, error) {
+	class, err := findVehicleClass(rs.repo, res.VehicleClass)
+	if err != nil {
+		return nil, err
+	}
+	customer, err := findCustomer(rs.repo, res.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	path, err := rs.upgradePath(class)
+	if err != nil {
+		return nil, err
+	}
+	// Members with free upgrades try the class above first, but only get a
+	// car from it that no reservation waiting for that class needs.
+	var loads classLoads
+	freeUpgrade := rs.loyaltyPolicy.freeUpgrade(res.LoyaltyTier) && len(path) > 1
+	if freeUpgrade {
+		path = append([]models.VehicleClass{path[1], path[0]}, path[2:]...)
+		if loads, err = rs.classLoads(0, 0, res.StartDate, res.EndDate, res.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, c := range path {
+		if rs.checkEligibility(customer, classCar(&c), res.StartDate, res.EndDate) != nil {
+			continue
+		}
+		cars, err := rs.freeCarsOfClass(c.Code, res.PickupBranchID, res.DropoffBranchID, res.StartDate, res.EndDate)
+		if err != nil {
+			return nil, err
+		}
+		if freeUpgrade && i == 0 {
+			cars = slices.DeleteFunc(cars, func(car models.Car) bool { return !loads.leaveRoomWithout(&car) })
+		}
+		if len(cars) == 0 {
+			continue
+		}
+		if err := rs.cheapestFirst(cars); err != nil {
+			return nil, err
+		}
+
+		car := cars[0]
+		pickup, dropoff, err := rs.resolveRoute(&car, res.PickupBranchID, res.DropoffBranchID, res.StartDate, res.EndDate, 0)
+		if err != nil {
+			return nil, err
+		}
+		return &carAssignment{car: car, upgraded: c.Code != class.Code, pickupBranchID: pickup, dropoffBranchID: dropoff}, nil
+	}
+	return nil, ErrClassSoldOut
+}
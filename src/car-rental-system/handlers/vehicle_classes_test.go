@@ -0,0 +1,171 @@
+package services
+
+import (
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"errors"
+	"testing"
+	"time"
+)
+
+func load(code string, rank, free, pending int) classLoad {
+	cars := make([]models.Car, free)
+	for i := range cars {
+		cars[i] = models.Car{ID: rank*100 + i, Class: code}
+	}
+	return classLoad{class: models.VehicleClass{Code: code, Rank: rank}, free: cars, pending: pending}
+}
+
+func TestClassLoadsSpare(t *testing.T) {
+	tests := []struct {
+		name  string
+		loads classLoads
+		class int
+		want  int
+	}{
+		{"empty class", classLoads{load("economy", 1, 0, 0)}, 0, 0},
+		{"own cars", classLoads{load("economy", 1, 2, 0)}, 0, 2},
+		{"upgrades into a higher class", classLoads{load("economy", 1, 1, 0), load("compact", 2, 1, 0)}, 0, 2},
+		{"higher class keeps its own cars", classLoads{load("economy", 1, 1, 0), load("compact", 2, 1, 0)}, 1, 1},
+		{"lower overflow uses the higher class", classLoads{load("economy", 1, 1, 2), load("compact", 2, 1, 0)}, 1, 0},
+		{"lower class left with its own car", classLoads{load("economy", 1, 1, 0), load("compact", 2, 0, 1), load("suv", 3, 1, 0)}, 0, 1},
+		{"higher demand cannot come down", classLoads{load("economy", 1, 2, 0), load("compact", 2, 0, 1)}, 0, 2},
+		{"higher demand takes the top car", classLoads{load("economy", 1, 0, 0), load("compact", 2, 0, 1), load("suv", 3, 1, 0)}, 0, 0},
+		{"same rank is not an upgrade", classLoads{load("economy", 1, 0, 0), load("eco-van", 1, 2, 0)}, 0, 0},
+		{"same rank shares the class above", classLoads{load("economy", 1, 0, 1), load("eco-van", 1, 0, 0), load("compact", 2, 1, 0)}, 1, 0},
+		{"overbooked above is not counted", classLoads{load("economy", 1, 1, 0), load("compact", 2, 0, 2)}, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.loads.spare(tt.class); got != tt.want {
+				t.Errorf("spare(%d) = %d, want %d", tt.class, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassLoadsLeaveRoomWithout(t *testing.T) {
+	full := classLoads{load("economy", 1, 1, 1), load("compact", 2, 1, 1)}
+	upgradable := classLoads{load("economy", 1, 1, 1), load("compact", 2, 2, 1)}
+	tests := []struct {
+		name  string
+		loads classLoads
+		car   models.Car
+		want  bool
+	}{
+		{"car without a class", full, models.Car{ID: 1}, true},
+		{"car not among the free ones", full, models.Car{ID: 999, Class: "economy"}, true},
+		{"last free car of a waiting class", full, full[0].free[0], false},
+		{"car a lower class waits for", full, full[1].free[0], false},
+		{"waiting booking can be upgraded", upgradable, upgradable[0].free[0], true},
+		{"spare car of the class", upgradable, upgradable[1].free[0], true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.loads.leaveRoomWithout(&tt.car); got != tt.want {
+				t.Errorf("leaveRoomWithout(%d) = %v, want %v", tt.car.ID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassBookingsCountAcrossUpgradePath(t *testing.T) {
+	rs, customer := newTestSystem(t)
+
+	book := func(carID int, class string) error {
+		_, err := rs.CreateReservation(ReservationRequest{
+			CustomerID:   customer.ID,
+			CarID:        carID,
+			VehicleClass: class,
+			StartDate:    "2025-03-10",
+			EndDate:      "2025-03-12",
+		})
+		return err
+	}
+
+	if err := book(0, "economy"); err != nil {
+		t.Fatalf("first economy booking: %v", err)
+	}
+	if ok, err := rs.IsCarAvailable(2, "2025-03-10", "2025-03-12"); err != nil || !ok {
+		t.Errorf("compact car with one economy booking waiting: available = %v, %v; want true", ok, err)
+	}
+	if err := book(0, "economy"); err != nil {
+		t.Fatalf("second economy booking: %v", err)
+	}
+	for i := 3; i <= 5; i++ {
+		if err := book(0, "economy"); !errors.Is(err, ErrClassSoldOut) {
+			t.Errorf("economy booking %d: got %v, want %v", i, err, ErrClassSoldOut)
+		}
+	}
+	if err := book(0, "compact"); !errors.Is(err, ErrClassSoldOut) {
+		t.Errorf("compact booking: got %v, want %v", err, ErrClassSoldOut)
+	}
+	if err := book(2, ""); !errors.Is(err, ErrCarNotAvailable) {
+		t.Errorf("compact car booked by id: got %v, want %v", err, ErrCarNotAvailable)
+	}
+	if err := book(1, ""); !errors.Is(err, ErrCarNotAvailable) {
+		t.Errorf("economy car booked by id: got %v, want %v", err, ErrCarNotAvailable)
+	}
+}
+
+func TestFreeUpgradeLeavesCarsForHigherClass(t *testing.T) {
+	gold := LoyaltyPolicy{
+		PointsPerUnit:    1,
+		PointValue:       money.New(1, money.USD),
+		TierWindowMonths: 12,
+		Tiers:            []TierRule{{Tier: models.TierGold, MinRentals: 1, FreeUpgrade: true}},
+	}
+	tests := []struct {
+		name          string
+		midsizeBooked bool
+		wantCar       int
+	}{
+		{"upper class has a spare car", false, 3},
+		{"upper class car is waited for", true, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs, customer := newTestSystem(t, WithLoyaltyPolicy(gold))
+			if _, err := rs.AddVehicleClass(models.VehicleClass{Code: "midsize", Name: "Midsize", Rank: 3, DailyRate: money.New(70_00, money.USD)}); err != nil {
+				t.Fatal(err)
+			}
+			if err := rs.AddCar(models.Car{ID: 3, Make: "Mazda", Model: "6", Year: 2022, LicensePlate: "MID333", RentalPricePerDay: money.New(75_00, money.USD), Class: "midsize"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := rs.repo.SaveLoyaltyEntry(&models.LoyaltyEntry{CustomerID: customer.ID, Kind: models.LoyaltyEarned, Points: 100, Spend: money.New(100_00, money.USD), At: testNow.AddDate(0, -1, 0)}); err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := rs.CreateReservation(ReservationRequest{CustomerID: customer.ID, VehicleClass: "compact", StartDate: "2025-03-10", EndDate: "2025-03-12"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.LoyaltyTier != models.TierGold {
+				t.Fatalf("booked at tier %q, want gold", res.LoyaltyTier)
+			}
+			var midsize *models.Reservation
+			if tt.midsizeBooked {
+				other, err := rs.RegisterCustomer(models.Customer{Name: "Bo Chen", Email: "bo@example.com", DateOfBirth: time.Date(1985, time.May, 5, 0, 0, 0, 0, time.UTC), DriversLicense: "B1234567", LicenseRegion: "US-CA", LicenseExpiry: time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if midsize, err = rs.CreateReservation(ReservationRequest{CustomerID: other.ID, VehicleClass: "midsize", StartDate: "2025-03-10", EndDate: "2025-03-12"}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			assigned, err := rs.AssignCar(res.ID)
+			if err != nil {
+				t.Fatalf("AssignCar: %v", err)
+			}
+			if assigned.CarID != tt.wantCar {
+				t.Errorf("gold booking got car %d, want %d", assigned.CarID, tt.wantCar)
+			}
+			if midsize != nil {
+				if assigned, err := rs.AssignCar(midsize.ID); err != nil || assigned.CarID != 3 {
+					t.Errorf("midsize booking: got %v, %v; want car 3", assigned, err)
+				}
+			}
+		})
+	}
+}
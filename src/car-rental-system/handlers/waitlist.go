@@ -0,0 +1,357 @@
+package services
+
+import (
+	"car-rental-system/events"
+	models "car-rental-system/rental_system_models"
+	"car-rental-system/repository"
+	"errors"
+	"time"
+)
+
+// defaultWaitlistHold is how long an offer stays open unless
+// WithWaitlistHold says otherwise.
+const defaultWaitlistHold = 2 * time.Hour
+
+func findWaitlistEntry(repo repository.Repository, entryID int) (*models.WaitlistEntry, error) {
+	entry, err := repo.WaitlistEntry(entryID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrWaitlistNotFound
+	}
+	return entry, err
+}
+
+// JoinWaitlist puts the customer in line for the car or class of req over
+// its dates, typically after CreateReservation reported it taken. When
+// the car or class is free already, the first customer in line for it is
+// offered it straight away, which may be this one. The promo code, points
+// and extras of req are kept for the booking made from the offer; they
+// are only fully checked then.
+func (rs *RentalSystem) JoinWaitlist(req ReservationRequest) (*models.WaitlistEntry, error) {
+	rs.mu.Lock()
+	defer rs.unlock()
+
+	start, end, err := rs.parseRentalPeriod(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	customer, err := findCustomer(rs.repo, req.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &models.WaitlistEntry{
+		Status:          models.WaitlistWaiting,
+		CustomerID:      customer.ID,
+		CarID:           req.CarID,
+		StartDate:       start,
+		EndDate:         end,
+		PickupBranchID:  req.PickupBranchID,
+		DropoffBranchID: req.DropoffBranchID,
+		JoinedAt:        rs.now(),
+		RedeemPoints:    req.RedeemPoints,
+	}
+	if req.RedeemPoints < 0 {
+		return nil, ErrInvalidPoints
+	}
+	if req.PromoCode != "" {
+		promo, err := findPromoCode(rs.repo, normalizePromoCode(req.PromoCode))
+		if err != nil {
+			return nil, err
+		}
+		entry.PromoCode = promo.Code
+	}
+	if entry.Extras, err = rs.lookupExtras(req.Extras, nil); err != nil {
+		return nil, err
+	}
+	if req.CarID != 0 {
+		car, err := findCar(rs.repo, req.CarID)
+		if err != nil {
+			return nil, err
+		}
+		if car.Status == models.CarRetired {
+			return nil, ErrCarRetired
+		}
+		entry.VehicleClass = car.Class
+	} else {
+		if req.VehicleClass == "" {
+			return nil, ErrCarOrClassRequired
+		}
+		class, err := findVehicleClass(rs.repo, normalizeClassCode(req.VehicleClass))
+		if err != nil {
+			return nil, err
+		}
+		entry.VehicleClass = class.Code
+		if entry.DropoffBranchID == 0 {
+			entry.DropoffBranchID = entry.PickupBranchID
+		}
+	}
+	// A branch that is closed at those times would never let the offer
+	// be taken up.
+	if err := rs.checkBranchOpen(entry.PickupBranchID, start); err != nil {
+		return nil, err
+	}
+	if err := rs.checkBranchOpen(entry.DropoffBranchID, end); err != nil {
+		return nil, err
+	}
+
+	existing, err := rs.repo.WaitlistEntries()
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range existing {
+		if other.Status.Open() && other.CustomerID == entry.CustomerID && other.CarID == entry.CarID &&
+			other.VehicleClass == entry.VehicleClass && other.StartDate.Before(end) && start.Before(other.EndDate) {
+			return nil, ErrAlreadyWaitlisted
+		}
+	}
+
+	if err := rs.repo.SaveWaitlistEntry(entry); err != nil {
+		return nil, err
+	}
+	rs.offerWaitlist()
+	return findWaitlistEntry(rs.repo, entry.ID)
+}
+
+// LeaveWaitlist takes the customer out of line. An open offer is turned
+// down and passes to the next customer.
+func (rs *RentalSystem) LeaveWaitlist(entryID int) error {
+	rs.mu.Lock()
+	defer rs.unlock()
+
+	entry, err := findWaitlistEntry(rs.repo, entryID)
+	if err != nil {
+		return err
+	}
+	if !entry.Status.Open() {
+		return ErrWaitlistEntryClosed
+	}
+	offered := entry.Status == models.WaitlistOffered
+	entry.Status = models.WaitlistWithdrawn
+	if err := rs.repo.SaveWaitlistEntry(entry); err != nil {
+		return err
+	}
+	if offered {
+		rs.calendar.releaseOffer(entry)
+		rs.offerWaitlist()
+	}
+	return nil
+}
+
+// AcceptWaitlistOffer books what the entry's offer holds, with the promo
+// code, points and extras the customer joined with, under the same checks
+// and pricing as CreateReservation.
+func (rs *RentalSystem) AcceptWaitlistOffer(entryID int) (*models.Reservation, error) {
+	rs.mu.Lock()
+	defer rs.unlock()
+
+	entry, err := findWaitlistEntry(rs.repo, entryID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Status != models.WaitlistOffered {
+		return nil, ErrNoWaitlistOffer
+	}
+	if !rs.now().Before(*entry.OfferExpiresAt) {
+		if err := rs.expireOffer(entry); err != nil {
+			return nil, err
+		}
+		rs.offerWaitlist()
+		return nil, ErrWaitlistOfferExpired
+	}
+
+	req := ReservationRequest{
+		CustomerID:      entry.CustomerID,
+		CarID:           entry.CarID,
+		StartDate:       entry.StartDate.Format(time.RFC3339),
+		EndDate:         entry.EndDate.Format(time.RFC3339),
+		PickupBranchID:  entry.PickupBranchID,
+		DropoffBranchID: entry.DropoffBranchID,
+		Extras:          extraRequests(entry.Extras),
+		PromoCode:       entry.PromoCode,
+		RedeemPoints:    entry.RedeemPoints,
+	}
+	if entry.CarID == 0 {
+		req.VehicleClass = entry.VehicleClass
+	}
+	// The hold is what keeps the booking possible; it makes way for the
+	// reservation and comes back if the booking fails after all.
+	rs.calendar.releaseOffer(entry)
+	res, err := rs.reserve(req, func(tx repository.Repository, res *models.Reservation) error {
+		booked := *entry
+		booked.Status = models.WaitlistBooked
+		booked.ReservationID = res.ID
+		return tx.SaveWaitlistEntry(&booked)
+	})
+	if err != nil {
+		rs.calendar.holdOffer(entry)
+		return nil, err
+	}
+	return res, nil
+}
+
+func (rs *RentalSystem) GetWaitlistEntry(entryID int) (*models.WaitlistEntry, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return findWaitlistEntry(rs.repo, entryID)
+}
+
+// ListWaitlist returns the entries still waiting or holding an offer, in
+// the order they will be served.
+func (rs *RentalSystem) ListWaitlist() ([]models.WaitlistEntry, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	entries, err := rs.repo.WaitlistEntries()
+	if err != nil {
+		return nil, err
+	}
+	var open []models.WaitlistEntry
+	for _, entry := range entries {
+		if entry.Status.Open() {
+			open = append(open, entry)
+		}
+	}
+	return open, nil
+}
+
+// CustomerWaitlist returns all of a customer's entries, closed ones
+// included, in the order they joined.
+func (rs *RentalSystem) CustomerWaitlist(customerID int) ([]models.WaitlistEntry, error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if _, err := findCustomer(rs.repo, customerID); err != nil {
+		return nil, err
+	}
+	entries, err := rs.repo.WaitlistEntries()
+	if err != nil {
+		return nil, err
+	}
+	var own []models.WaitlistEntry
+	for _, entry := range entries {
+		if entry.CustomerID == customerID {
+			own = append(own, entry)
+		}
+	}
+	return own, nil
+}
+
+// expireWaitlistOffers closes the offers that ran out unused and returns
+// how many there were. What they held is offered on by the next
+// offerWaitlist.
+func (rs *RentalSystem) expireWaitlistOffers() (int, error) {
+	entries, err := rs.repo.WaitlistEntries()
+	if err != nil {
+		return 0, err
+	}
+	now := rs.now()
+	expired := 0
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Status != models.WaitlistOffered || now.Before(*entry.OfferExpiresAt) {
+			continue
+		}
+		if err := rs.expireOffer(entry); err != nil {
+			return expired, err
+		}
+		expired++
+	}
+	return expired, nil
+}
+
+// expireOffer closes an offer that was not taken up in time and frees what
+// it held.
+func (rs *RentalSystem) expireOffer(entry *models.WaitlistEntry) error {
+	entry.Status = models.WaitlistExpired
+	if err := rs.repo.SaveWaitlistEntry(entry); err != nil {
+		return err
+	}
+	rs.calendar.releaseOffer(entry)
+	rs.publish(events.WaitlistOfferExpired{Entry: *entry, At: rs.now()})
+	return nil
+}
+
+// offerWaitlist goes through the waiting customers in the order they
+// joined and offers each one whose car or class has come free a hold on
+// it. Customers who may not drive it are passed over and keep their
+// place. It runs after every change that can free capacity; a failure
+// leaves the entries waiting for the next run.
+func (rs *RentalSystem) offerWaitlist() {
+	entries, err := rs.repo.WaitlistEntries()
+	if err != nil {
+		return
+	}
+	now := rs.now()
+	today := startOfDay(now)
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Status != models.WaitlistWaiting {
+			continue
+		}
+		if entry.StartDate.Before(today) {
+			// The rental was due to start without anything coming free.
+			entry.Status = models.WaitlistExpired
+			if rs.repo.SaveWaitlistEntry(entry) != nil {
+				return
+			}
+			continue
+		}
+		pickup, ok := rs.canOffer(entry)
+		if !ok {
+			continue
+		}
+
+		expires := now.Add(rs.waitlistHold)
+		entry.Status = models.WaitlistOffered
+		entry.PickupBranchID = pickup
+		entry.OfferedAt = &now
+		entry.OfferExpiresAt = &expires
+		if rs.repo.SaveWaitlistEntry(entry) != nil {
+			return
+		}
+		rs.calendar.holdOffer(entry)
+		rs.publish(events.WaitlistOfferMade{Entry: *entry, At: now})
+	}
+}
+
+// canOffer reports whether the entry could be booked right now, with the
+// checks a reservation would have to pass, and the branch its car would be
+// picked up from.
+func (rs *RentalSystem) canOffer(entry *models.WaitlistEntry) (int, bool) {
+	customer, err := findCustomer(rs.repo, entry.CustomerID)
+	if err != nil {
+		return 0, false
+	}
+	pickup := entry.PickupBranchID
+	if entry.CarID != 0 {
+		car, err := findCar(rs.repo, entry.CarID)
+		if err != nil {
+			return 0, false
+		}
+		if ok, err := rs.carBookable(car, entry.StartDate, entry.EndDate, 0); err != nil || !ok {
+			return 0, false
+		}
+		if pickup, _, err = rs.resolveRoute(car, entry.PickupBranchID, entry.DropoffBranchID, entry.StartDate, entry.EndDate, 0); err != nil {
+			return 0, false
+		}
+		if rs.checkEligibility(customer, car, entry.StartDate, entry.EndDate) != nil {
+			return 0, false
+		}
+	} else {
+		class, err := findVehicleClass(rs.repo, entry.VehicleClass)
+		if err != nil {
+			return 0, false
+		}
+		if rs.checkClassCapacity(class, entry.PickupBranchID, entry.DropoffBranchID, entry.StartDate, entry.EndDate, 0) != nil {
+			return 0, false
+		}
+		if rs.checkEligibility(customer, classCar(class), entry.StartDate, entry.EndDate) != nil {
+			return 0, false
+		}
+	}
+	// The offer keeps the extras back too, so they must be free as well.
+	if rs.checkExtraStock(entry.Extras, pickup, entry.StartDate, entry.EndDate, 0) != nil {
+		return 0, false
+	}
+	return pickup, true
+}
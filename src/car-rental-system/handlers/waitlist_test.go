@@ -0,0 +1,83 @@
+package services
+
+import (
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"testing"
+	"time"
+)
+
+func TestFleetChangesOfferWaitlist(t *testing.T) {
+	tests := []struct {
+		name string
+		// join puts the customer in line for something that is not free
+		// yet; free then makes it free.
+		join func(t *testing.T, rs *RentalSystem, customerID int) ReservationRequest
+		free func(t *testing.T, rs *RentalSystem)
+	}{
+		{"car relocated to the pickup branch",
+			func(t *testing.T, rs *RentalSystem, customerID int) ReservationRequest {
+				downtown, err := rs.AddBranch(models.Branch{Name: "Downtown"})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, err := rs.RelocateCar(1, downtown.ID); err != nil {
+					t.Fatal(err)
+				}
+				airport, err := rs.AddBranch(models.Branch{Name: "Airport"})
+				if err != nil {
+					t.Fatal(err)
+				}
+				return ReservationRequest{CustomerID: customerID, CarID: 1, PickupBranchID: airport.ID, StartDate: "2025-03-10", EndDate: "2025-03-12"}
+			},
+			func(t *testing.T, rs *RentalSystem) {
+				branches, err := rs.ListBranches()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, err := rs.RelocateCar(1, branches[len(branches)-1].ID); err != nil {
+					t.Fatal(err)
+				}
+			}},
+		{"car added to the class",
+			func(t *testing.T, rs *RentalSystem, customerID int) ReservationRequest {
+				if _, err := rs.CreateReservation(ReservationRequest{CustomerID: customerID, CarID: 2, StartDate: "2025-03-10", EndDate: "2025-03-12"}); err != nil {
+					t.Fatal(err)
+				}
+				return ReservationRequest{CustomerID: customerID, VehicleClass: "compact", StartDate: "2025-03-11", EndDate: "2025-03-13"}
+			},
+			func(t *testing.T, rs *RentalSystem) {
+				if err := rs.AddCar(models.Car{ID: 3, Make: "Mazda", Model: "3", Year: 2023, Class: "compact", LicensePlate: "MAZ123", RentalPricePerDay: money.New(55_00, money.USD)}); err != nil {
+					t.Fatal(err)
+				}
+			}},
+		{"car back in service",
+			func(t *testing.T, rs *RentalSystem, customerID int) ReservationRequest {
+				if _, err := rs.SetCarStatus(1, models.CarInMaintenance); err != nil {
+					t.Fatal(err)
+				}
+				return ReservationRequest{CustomerID: customerID, CarID: 1, StartDate: "2025-03-10", EndDate: "2025-03-12"}
+			},
+			func(t *testing.T, rs *RentalSystem) {
+				if _, err := rs.SetCarStatus(1, models.CarActive); err != nil {
+					t.Fatal(err)
+				}
+			}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs, customer := newTestSystem(t, WithWaitlistHold(time.Hour))
+			entry, err := rs.JoinWaitlist(tt.join(t, rs, customer.ID))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if entry.Status != models.WaitlistWaiting {
+				t.Fatalf("entry = %+v, want it waiting", entry)
+			}
+			tt.free(t, rs)
+			if entry, err = rs.GetWaitlistEntry(entry.ID); err != nil || entry.Status != models.WaitlistOffered {
+				t.Errorf("entry = %+v, %v; want an offer", entry, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package invoicing
+
+import (
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+var csvHeader = []string{
+	"number", "kind", "issued_at", "reservation_id", "customer_id", "customer_name",
+	"currency", "subtotal", "tax_total", "total", "amount_settled", "status",
+}
+
+// signed returns amount as it counts in the ledger: credit notes take
+// money off.
+func signed(invoice *models.Invoice, amount money.Money) string {
+	if invoice.Kind == models.InvoiceKindCreditNote {
+		amount = amount.Neg()
+	}
+	return amount.Decimal()
+}
+
+// WriteCSV exports one row per document for the accounting ledger. Credit
+// note amounts are negative so the columns can be summed per currency.
+func WriteCSV(w io.Writer, invoices []models.Invoice) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(csvHeader); err != nil {
+		return err
+	}
+	for i := range invoices {
+		invoice := &invoices[i]
+		row := []string{
+			invoice.Number,
+			string(invoice.Kind),
+			invoice.IssuedAt.UTC().Format(time.RFC3339),
+			strconv.Itoa(invoice.ReservationID),
+			strconv.Itoa(invoice.CustomerID),
+			invoice.BillTo.Name,
+			string(invoice.Total.Currency),
+			signed(invoice, invoice.Subtotal),
+			signed(invoice, invoice.TaxTotal),
+			signed(invoice, invoice.Total),
+			signed(invoice, invoice.AmountSettled),
+			string(invoice.Status),
+		}
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// WriteJSON exports the documents with their lines and taxes.
+func WriteJSON(w io.Writer, invoices []models.Invoice) error {
+	if invoices == nil {
+		invoices = []models.Invoice{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(invoices)
+}
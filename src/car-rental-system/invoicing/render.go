@@ -0,0 +1,117 @@
+// Package invoicing renders invoices and credit notes for customers and
+// exports them for accounting.
+package invoicing
+
+import (
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const dateLayout = "2006-01-02"
+
+// Title is the heading printed on the document.
+func Title(invoice *models.Invoice) string {
+	if invoice.Kind == models.InvoiceKindCreditNote {
+		return "Credit note"
+	}
+	return "Invoice"
+}
+
+func billToLines(party models.BillingParty) []string {
+	lines := []string{party.Name}
+	address := party.Address
+	for _, line := range []string{address.Street, strings.TrimSpace(address.PostalCode + " " + address.City), address.Country, party.Email} {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// RenderText writes the document as plain text, e.g. for email bodies.
+func RenderText(w io.Writer, invoice *models.Invoice) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", Title(invoice), invoice.Number)
+	fmt.Fprintf(&b, "Issued: %s\n", invoice.IssuedAt.Format(dateLayout))
+	fmt.Fprintf(&b, "Reservation: %d\n", invoice.ReservationID)
+	if invoice.Reason != "" {
+		fmt.Fprintf(&b, "Reason: %s\n", invoice.Reason)
+	}
+	b.WriteString("\nBill to:\n")
+	for _, line := range billToLines(invoice.BillTo) {
+		fmt.Fprintf(&b, "  %s\n", line)
+	}
+	b.WriteString("\n")
+
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Description\tQty\tUnit price\tAmount\t")
+	for _, line := range invoice.Lines {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t\n", line.Description, line.Quantity, line.UnitPrice.Decimal(), line.Amount.Decimal())
+	}
+	fmt.Fprintln(tw, "\t\t\t--------\t")
+	fmt.Fprintf(tw, "Subtotal\t\t\t%s\t\n", invoice.Subtotal.Decimal())
+	for _, tax := range invoice.Taxes {
+		fmt.Fprintf(tw, "%s %g%%\t\t\t%s\t\n", tax.Name, tax.Percent, tax.Amount.Decimal())
+	}
+	fmt.Fprintf(tw, "Total (%s)\t\t\t%s\t\n", invoice.Total.Currency, invoice.Total.Decimal())
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if invoice.Kind == models.InvoiceKindInvoice {
+		fmt.Fprintf(&b, "\nStatus: %s (%s of %s settled)\n", invoice.Status, invoice.AmountSettled, invoice.Total)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var htmlTemplate = template.Must(template.New("invoice").Funcs(template.FuncMap{
+	"money": func(amount money.Money) string { return amount.Decimal() },
+	"date":  func(inv *models.Invoice) string { return inv.IssuedAt.Format(dateLayout) },
+	"title": Title,
+	"billTo": func(inv *models.Invoice) []string {
+		return billToLines(inv.BillTo)
+	},
+	"isInvoice": func(inv *models.Invoice) bool { return inv.Kind == models.InvoiceKindInvoice },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{title .}} {{.Number}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; width: 100%; }
+th, td { padding: 4px 8px; border-bottom: 1px solid #ddd; }
+td.num, th.num { text-align: right; }
+tfoot td { font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>{{title .}} {{.Number}}</h1>
+<p>Issued {{date .}} for reservation {{.ReservationID}}{{if .Reason}} ({{.Reason}}){{end}}</p>
+<address>{{range billTo .}}{{.}}<br>{{end}}</address>
+<table>
+<thead><tr><th>Description</th><th class="num">Qty</th><th class="num">Unit price</th><th class="num">Amount</th></tr></thead>
+<tbody>
+{{range .Lines}}<tr><td>{{.Description}}</td><td class="num">{{.Quantity}}</td><td class="num">{{money .UnitPrice}}</td><td class="num">{{money .Amount}}</td></tr>
+{{end}}</tbody>
+<tfoot>
+<tr><td colspan="3">Subtotal</td><td class="num">{{money .Subtotal}}</td></tr>
+{{range .Taxes}}<tr><td colspan="3">{{.Name}} {{.Percent}}%</td><td class="num">{{money .Amount}}</td></tr>
+{{end}}<tr><td colspan="3">Total ({{.Total.Currency}})</td><td class="num">{{money .Total}}</td></tr>
+</tfoot>
+</table>
+{{if isInvoice .}}<p>Status: {{.Status}} ({{.AmountSettled}} of {{.Total}} settled)</p>{{end}}
+</body>
+</html>
+`))
+
+// RenderHTML writes the document as a standalone HTML page.
+func RenderHTML(w io.Writer, invoice *models.Invoice) error {
+	return htmlTemplate.Execute(w, invoice)
+}
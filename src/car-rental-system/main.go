@@ -1,46 +1,186 @@
 package main
 
 import (
+	"car-rental-system/api"
+	"car-rental-system/eligibility"
+	"car-rental-system/events"
 	services "car-rental-system/handlers"
+	"car-rental-system/money"
+	"car-rental-system/pricing"
 	models "car-rental-system/rental_system_models"
-	"fmt"
+	"car-rental-system/repository"
+	"flag"
+	"log"
+	"time"
 )
 
-func main() {
-	// Initialize Rental System
-	rentalSystem := services.NewRentalSystem()
+// openRepository picks the storage backend from the command line flags,
+// falling back to memory when none is set.
+func openRepository(sqlitePath, mysqlDSN, journalDir string) (repository.Repository, error) {
+	switch {
+	case journalDir != "":
+		return repository.OpenJournal(journalDir)
+	case mysqlDSN != "":
+		return repository.OpenMySQL(mysqlDSN)
+	case sqlitePath != "":
+		return repository.OpenSQLite(sqlitePath)
+	default:
+		return repository.NewMemoryRepository(), nil
+	}
+}
+
+// pricingEngine is the rate card used by the counter: seasonal rates and
+// surcharges first, then long-rental discounts, then per-driver fees and
+// extras, which are always charged at the catalogue price, and finally any
+// promo code and loyalty points redeemed. Gold members pay no young-driver
+// fee.
+func pricingEngine() *pricing.Engine {
+	return pricing.NewEngine(
+		pricing.BaseRate{},
+		pricing.SeasonalRates{Seasons: []pricing.Season{
+			{Name: "Summer", Start: pricing.MonthDay{Month: time.June, Day: 15}, End: pricing.MonthDay{Month: time.September, Day: 15}, Multiplier: 1.25},
+			{Name: "Winter holidays", Start: pricing.MonthDay{Month: time.December, Day: 20}, End: pricing.MonthDay{Month: time.January, Day: 5}, Multiplier: 1.3},
+		}},
+		pricing.WeekendSurcharge{Percent: 10},
+		pricing.HolidaySurcharge{Percent: 20, Holidays: []time.Time{
+			time.Date(2026, time.December, 25, 0, 0, 0, 0, time.UTC),
+			time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC),
+		}},
+		pricing.LongRentalDiscount{Tiers: []pricing.DiscountTier{
+			{Name: "Weekly", MinDays: 7, Percent: 10},
+			{Name: "Monthly", MinDays: 28, Percent: 25},
+		}},
+		pricing.YoungDriverFee{MinAge: 25, FeePerDay: money.New(15_00, money.USD), WaivedTiers: []models.LoyaltyTier{models.TierGold}},
+		pricing.OneWayFee{Fee: money.New(75_00, money.USD)},
+		pricing.ExtraCharges{},
+		pricing.PromoDiscount{},
+		pricing.LoyaltyRedemption{},
+	)
+}
+
+// eligibilityChecker holds the counter's driver requirements. Sports and
+// luxury cars need older drivers, and sports cars are not rented to drivers
+// over 75.
+func eligibilityChecker() *eligibility.Checker {
+	return eligibility.NewChecker(
+		eligibility.LicenseFormat{Formats: eligibility.DefaultLicenseFormats()},
+		eligibility.LicenseExpiry{},
+		eligibility.AgeLimits{
+			Default: eligibility.AgeRange{Min: 21},
+			Classes: map[string]eligibility.AgeRange{
+				"luxury": {Min: 25},
+				"sports": {Min: 25, Max: 75},
+			},
+		},
+	)
+}
+
+// vehicleClasses is the class list the fleet is sold by, cheapest first.
+func vehicleClasses() []models.VehicleClass {
+	return []models.VehicleClass{
+		{Code: "economy", Name: "Economy", Rank: 1, DailyRate: money.New(45_00, money.USD), Seats: 4, Transmission: models.TransmissionManual, FuelType: models.FuelPetrol, LuggageCapacity: 1},
+		{Code: "compact", Name: "Compact", Rank: 2, DailyRate: money.New(55_00, money.USD), Seats: 5, Transmission: models.TransmissionManual, FuelType: models.FuelPetrol, LuggageCapacity: 2},
+		{Code: "suv", Name: "SUV", Rank: 3, DailyRate: money.New(85_00, money.USD), Seats: 5, Transmission: models.TransmissionAutomatic, FuelType: models.FuelHybrid, LuggageCapacity: 4},
+		{Code: "van", Name: "Van", Rank: 4, DailyRate: money.New(110_00, money.USD), Seats: 8, Transmission: models.TransmissionAutomatic, FuelType: models.FuelDiesel, LuggageCapacity: 6},
+	}
+}
+
+// exchangeRates seeds the exchange-rate table with the currencies the
+// branches abroad charge in.
+func exchangeRates() []money.Rate {
+	return []money.Rate{
+		{From: money.USD, To: money.EUR, Value: 0.92},
+		{From: money.USD, To: money.GBP, Value: 0.79},
+	}
+}
+
+// auditLog writes every domain event to the server log.
+func auditLog(e events.Event) {
+	log.Printf("event %s at %s", e.Name(), e.OccurredAt().Format(time.RFC3339))
+}
 
-	// Adding cars
-	rentalSystem.AddCar(models.Car{ID: 1, Make: "Toyota", Model: "Corolla", Year: 2020, LicensePlate: "ABC123", RentalPricePerDay: 50, IsAvailable: true})
-	rentalSystem.AddCar(models.Car{ID: 2, Make: "Honda", Model: "Civic", Year: 2021, LicensePlate: "XYZ789", RentalPricePerDay: 60, IsAvailable: true})
+// openDaily returns opening hours that are the same every day of the week.
+func openDaily(open, close string) []models.OpeningHours {
+	hours := make([]models.OpeningHours, 0, 7)
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		hours = append(hours, models.OpeningHours{Weekday: weekday, Open: open, Close: close})
+	}
+	return hours
+}
 
-	// Searching cars
-	fmt.Println("Available Cars:", rentalSystem.SearchCars("Toyota", 100))
+func main() {
+	sqlitePath := flag.String("sqlite", "", "path of a SQLite database file")
+	mysqlDSN := flag.String("mysql", "", "MySQL DSN, e.g. user:pass@tcp(127.0.0.1:3306)/rentals?parseTime=true")
+	addr := flag.String("addr", "localhost:8080", "address the HTTP API listens on")
+	journalDir := flag.String("journal", "", "directory of an event-sourced journal")
+	asOf := flag.String("as-of", "", "with -journal, serve the state as it was at this RFC 3339 time; changes are not saved")
+	demo := flag.Bool("demo", false, "run the demo script instead of the HTTP API")
+	flag.Parse()
 
-	// Creating reservation
-	customer := models.Customer{Name: "John Doe", ContactDetails: "john.doe@example.com", DriversLicense: "D123456"}
-	reservation, err := rentalSystem.CreateReservation(customer, 1, "2025-03-29", "2025-03-30")
-	if err == nil {
-		fmt.Println("Reservation created:", *reservation)
+	var repo repository.Repository
+	var err error
+	if *asOf != "" {
+		if *journalDir == "" {
+			log.Fatal("-as-of needs -journal")
+		}
+		at, parseErr := time.Parse(time.RFC3339, *asOf)
+		if parseErr != nil {
+			log.Fatalf("invalid -as-of time: %v", parseErr)
+		}
+		repo, err = repository.RebuildAt(*journalDir, at)
+	} else {
+		repo, err = openRepository(*sqlitePath, *mysqlDSN, *journalDir)
+	}
+	if err != nil {
+		log.Fatalf("failed to open storage: %v", err)
+	}
+	if journal, ok := repo.(*repository.JournalRepository); ok {
+		defer journal.Close()
 	}
 
-	// Processing payment
-	if err := rentalSystem.ProcessPayment(reservation.ID); err == nil {
-		fmt.Println("Payment successful")
+	bus := events.NewBus()
+	defer bus.Close()
+
+	// Initialize Rental System
+	rentalSystem, err := services.NewRentalSystemWithRepository(repo,
+		services.WithEventBus(bus),
+		services.WithPricingEngine(pricingEngine()),
+		services.WithEligibilityChecker(eligibilityChecker()),
+		services.WithCancellationPolicy(models.CancellationPolicy{Name: "Flexible", FreeCancellationHours: 48, LateFeePercent: 20}),
+		services.WithTaxRates(models.TaxRate{Name: "Sales tax", Percent: 7.25}),
+	)
+	if err != nil {
+		log.Fatalf("failed to load rental system: %v", err)
 	}
 
-	// Modifying reservation
-	if err := rentalSystem.ModifyReservation(reservation.ID, "2025-04-01", "2025-04-06"); err == nil {
-		fmt.Println("Reservation modified successfully")
+	if *demo {
+		runDemo(rentalSystem, bus)
+		return
 	}
+	bus.SubscribeAsync(events.SubscriberFunc(auditLog), 64)
 
-	// Canceling reservation
-	if err := rentalSystem.CancelReservation(reservation.ID); err == nil {
-		fmt.Println("Reservation cancelled.")
+	// Seed an empty fleet so the API has something to serve.
+	if cars, err := rentalSystem.ListCars(); err == nil && len(cars) == 0 {
+		for _, class := range vehicleClasses() {
+			rentalSystem.AddVehicleClass(class)
+		}
+		for _, rate := range exchangeRates() {
+			rentalSystem.AddExchangeRate(rate)
+		}
+		downtown, _ := rentalSystem.AddBranch(models.Branch{Name: "Downtown", OpeningHours: openDaily("08:00", "20:00")})
+		airport, _ := rentalSystem.AddBranch(models.Branch{Name: "Airport", OpeningHours: openDaily("05:00", "23:30")})
+		rentalSystem.AddCar(models.Car{ID: 1, Make: "Toyota", Model: "Corolla", Year: 2020, LicensePlate: "ABC123", RentalPricePerDay: money.New(50_00, money.USD), Class: "economy", BranchID: downtown.ID})
+		rentalSystem.AddCar(models.Car{ID: 2, Make: "Honda", Model: "Civic", Year: 2021, LicensePlate: "XYZ789", RentalPricePerDay: money.New(60_00, money.USD), Class: "compact", BranchID: airport.ID})
 	}
 
-	//Checking for the car availability
-	if availability, err := rentalSystem.IsCarAvailableOnDate(1, "2025-04-02"); err == nil {
-		fmt.Println("Is the car available:", availability)
+	// Expired holds and waitlist offers free their cars within seconds.
+	stopReaper := rentalSystem.StartReaper(15*time.Second, func(err error) {
+		log.Printf("reaper: %v", err)
+	})
+	defer stopReaper()
+
+	log.Printf("HTTP API listening on %s", *addr)
+	if err := api.NewServer(rentalSystem).Router().Run(*addr); err != nil {
+		log.Fatalf("server failed: %v", err)
 	}
 }
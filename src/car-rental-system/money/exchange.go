@@ -0,0 +1,80 @@
+package money
+
+import (
+	"errors"
+	"math/big"
+	"time"
+)
+
+var ErrNoRate = errors.New("money: no exchange rate between these currencies")
+
+// Rate is what one unit of From is worth in To, in force from
+// EffectiveFrom until a later rate for the same pair takes over. Value is
+// read as the decimal it prints as, so 1.17 is exactly 1.17.
+type Rate struct {
+	From          Currency  `json:"from"`
+	To            Currency  `json:"to"`
+	Value         float64   `json:"rate"`
+	EffectiveFrom time.Time `json:"effectiveFrom"`
+}
+
+// Rates is an exchange-rate table. A pair converts both ways: without a
+// rate from EUR to GBP, the inverse of the GBP to EUR rate is used.
+type Rates []Rate
+
+// find returns the exact rate from one currency to another in force at
+// at, preferring the most recent of the direct and inverse rates.
+func (rs Rates) find(from, to Currency, at time.Time) (*big.Rat, bool) {
+	var best *Rate
+	inverse := false
+	for i := range rs {
+		rate := &rs[i]
+		if rate.Value <= 0 || rate.EffectiveFrom.After(at) {
+			continue
+		}
+		direct := rate.From == from && rate.To == to
+		if !direct && !(rate.From == to && rate.To == from) {
+			continue
+		}
+		if best == nil || rate.EffectiveFrom.After(best.EffectiveFrom) || (direct && inverse && rate.EffectiveFrom.Equal(best.EffectiveFrom)) {
+			best, inverse = rate, !direct
+		}
+	}
+	if best == nil {
+		return nil, false
+	}
+	value := decimal(best.Value)
+	if inverse {
+		value.Inv(value)
+	}
+	return value, true
+}
+
+// Convert converts m into currency to at the rates in force at at, rounding
+// the result to to's minor unit with r.
+func (rs Rates) Convert(m Money, to Currency, at time.Time, r Rounding) (Money, error) {
+	if m.Currency == to || m.IsZero() {
+		return New(m.Amount, to), nil
+	}
+	rate, ok := rs.find(m.Currency, to, at)
+	if !ok {
+		return Money{}, ErrNoRate
+	}
+	x := new(big.Rat).SetInt64(m.Amount)
+	x.Mul(x, rate)
+	x.Mul(x, pow10(to.Exponent()))
+	x.Quo(x, pow10(m.Currency.Exponent()))
+	return New(r.round(x), to), nil
+}
+
+// Exchange converts at the rates in force at one moment, always rounding
+// the same way.
+type Exchange struct {
+	Rates    Rates
+	At       time.Time
+	Rounding Rounding
+}
+
+func (e Exchange) Convert(m Money, to Currency) (Money, error) {
+	return e.Rates.Convert(m, to, e.At, e.Rounding)
+}
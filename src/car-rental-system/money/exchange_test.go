@@ -0,0 +1,51 @@
+package money
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConvert(t *testing.T) {
+	jan := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)
+	rates := Rates{
+		{From: EUR, To: USD, Value: 1.17, EffectiveFrom: jan},
+		{From: EUR, To: USD, Value: 1.2, EffectiveFrom: feb},
+		{From: GBP, To: EUR, Value: 1.15, EffectiveFrom: jan},
+		{From: USD, To: "JPY", Value: 150.25, EffectiveFrom: jan},
+		{From: USD, To: EUR, Value: 0.9, EffectiveFrom: feb},
+	}
+	tests := []struct {
+		name     string
+		amount   Money
+		to       Currency
+		at       time.Time
+		rounding Rounding
+		want     Money
+		wantErr  error
+	}{
+		{"same currency", New(1000, EUR), EUR, jan, HalfUp, New(1000, EUR), nil},
+		{"zero needs no rate", New(0, EUR), "CHF", jan, HalfUp, New(0, "CHF"), nil},
+		{"direct rate", New(1000, EUR), USD, jan, HalfUp, New(1170, USD), nil},
+		{"later rate takes over", New(1000, EUR), USD, feb.AddDate(0, 0, 3), HalfUp, New(1200, USD), nil},
+		{"inverse rate", New(1150, EUR), GBP, jan, HalfUp, New(1000, GBP), nil},
+		{"inverse rounds", New(1000, EUR), GBP, jan, HalfUp, New(870, GBP), nil},
+		{"direct preferred on the same day", New(1000, USD), EUR, feb, HalfUp, New(900, EUR), nil},
+		{"more recent inverse preferred", New(1200, USD), EUR, jan.AddDate(0, 0, 10), HalfUp, New(1026, EUR), nil},
+		{"into a currency without cents", New(1001, USD), "JPY", jan, HalfUp, New(1504, "JPY"), nil},
+		{"out of a currency without cents", New(15025, "JPY"), USD, jan, HalfUp, New(10000, USD), nil},
+		{"half even", New(1, USD), "JPY", jan, HalfEven, New(2, "JPY"), nil},
+		{"down", New(1, USD), "JPY", jan, Down, New(1, "JPY"), nil},
+		{"not yet in force", New(1000, EUR), USD, jan.AddDate(0, 0, -1), HalfUp, Money{}, ErrNoRate},
+		{"no rate", New(1000, EUR), "CHF", jan, HalfUp, Money{}, ErrNoRate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Exchange{Rates: rates, At: tt.at, Rounding: tt.rounding}.Convert(tt.amount, tt.to)
+			if got != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("Convert(%v, %s) = %v, %v; want %v, %v", tt.amount, tt.to, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,316 @@
+// Package money holds amounts exactly, as a whole number of their
+// currency's minor unit (cents, pence), so adding up prices never picks up
+// the rounding errors of floating point. Wherever an amount has to be
+// divided, scaled or converted, the caller says how to round.
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidCurrency = errors.New("money: currency must be a three-letter ISO 4217 code")
+	ErrInvalidAmount   = errors.New("money: invalid amount")
+	// ErrCurrencyMismatch is returned by the checked operations when the
+	// amounts are in different currencies.
+	ErrCurrencyMismatch = errors.New("money: amounts are in different currencies")
+)
+
+// Currency is an ISO 4217 currency code, such as "EUR".
+type Currency string
+
+const (
+	USD Currency = "USD"
+	EUR Currency = "EUR"
+	GBP Currency = "GBP"
+)
+
+// exponents lists the currencies whose minor unit is not a hundredth.
+var exponents = map[Currency]int{
+	"BHD": 3, "ISK": 0, "JOD": 3, "JPY": 0, "KRW": 0, "KWD": 3, "OMR": 3, "TND": 3,
+}
+
+// ParseCurrency reads a currency code such as "eur" as EUR.
+func ParseCurrency(code string) (Currency, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return "", ErrInvalidCurrency
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return "", ErrInvalidCurrency
+		}
+	}
+	return Currency(code), nil
+}
+
+// Exponent is the number of decimal places of the currency's minor unit.
+func (c Currency) Exponent() int {
+	if exp, ok := exponents[c]; ok {
+		return exp
+	}
+	return 2
+}
+
+// Rounding says which way an amount falling between two minor units goes.
+// The zero Rounding is HalfUp.
+type Rounding string
+
+const (
+	// HalfUp rounds to the nearest minor unit and halves away from zero,
+	// as customers expect on a bill.
+	HalfUp Rounding = "half_up"
+	// HalfEven rounds halves to the even neighbour, so converting many
+	// amounts does not drift in one direction.
+	HalfEven Rounding = "half_even"
+	// Down drops whatever is below the minor unit.
+	Down Rounding = "down"
+	// Up rounds any fraction of a minor unit away from zero.
+	Up Rounding = "up"
+)
+
+func (r Rounding) Valid() bool {
+	switch r {
+	case "", HalfUp, HalfEven, Down, Up:
+		return true
+	}
+	return false
+}
+
+// round rounds x to a whole number.
+func (r Rounding) round(x *big.Rat) int64 {
+	q, rem := new(big.Int).QuoRem(x.Num(), x.Denom(), new(big.Int))
+	if rem.Sign() == 0 {
+		return q.Int64()
+	}
+	away := big.NewInt(int64(x.Sign()))
+	half := new(big.Int).Abs(rem)
+	half.Lsh(half, 1)
+	switch cmp := half.Cmp(x.Denom()); r {
+	case Down:
+		return q.Int64()
+	case Up:
+		return q.Add(q, away).Int64()
+	case HalfEven:
+		if cmp > 0 || (cmp == 0 && q.Bit(0) == 1) {
+			q.Add(q, away)
+		}
+		return q.Int64()
+	default:
+		if cmp >= 0 {
+			q.Add(q, away)
+		}
+		return q.Int64()
+	}
+}
+
+// decimal reads f as the shortest decimal that prints as f, so a rate of
+// 1.17 is exactly 117/100 rather than the binary number nearest to it.
+func decimal(f float64) *big.Rat {
+	x, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, 64))
+	if !ok {
+		return new(big.Rat)
+	}
+	return x
+}
+
+// Money is an amount in the minor unit of its currency: New(1999, EUR) is
+// 19.99 EUR. The zero Money has no currency and counts as zero in any
+// currency, so it can start a sum.
+type Money struct {
+	Amount   int64
+	Currency Currency
+}
+
+func New(amount int64, currency Currency) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Parse reads a decimal amount such as "19.99" in currency. It refuses
+// more decimal places than the currency's minor unit has.
+func Parse(amount string, currency Currency) (Money, error) {
+	x, ok := new(big.Rat).SetString(strings.TrimSpace(amount))
+	if !ok || strings.ContainsAny(amount, "eE/") {
+		return Money{}, ErrInvalidAmount
+	}
+	x.Mul(x, pow10(currency.Exponent()))
+	if !x.IsInt() || !x.Num().IsInt64() {
+		return Money{}, ErrInvalidAmount
+	}
+	return New(x.Num().Int64(), currency), nil
+}
+
+func pow10(exp int) *big.Rat {
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil))
+}
+
+// SameCurrency reports whether m and o can be added, subtracted or
+// compared: they are in the same currency, or one of them is a zero
+// without one. Callers holding amounts from different sources check it
+// before combining them.
+func (m Money) SameCurrency(o Money) bool {
+	return m.Currency == o.Currency || (m.Currency == "" && m.Amount == 0) || (o.Currency == "" && o.Amount == 0)
+}
+
+// currencyWith returns the currency of m combined with o. Combining two
+// currencies is a bug in the caller, which should have converted first or
+// used the checked operations.
+func (m Money) currencyWith(o Money) Currency {
+	if !m.SameCurrency(o) {
+		panic(fmt.Sprintf("money: cannot combine %s and %s", m.Currency, o.Currency))
+	}
+	if m.Currency == "" {
+		return o.Currency
+	}
+	return m.Currency
+}
+
+func (m Money) Add(o Money) Money {
+	return New(m.Amount+o.Amount, m.currencyWith(o))
+}
+
+func (m Money) Sub(o Money) Money {
+	return New(m.Amount-o.Amount, m.currencyWith(o))
+}
+
+// CheckedAdd is Add for amounts that may come from different sources: it
+// returns ErrCurrencyMismatch rather than panic.
+func (m Money) CheckedAdd(o Money) (Money, error) {
+	if !m.SameCurrency(o) {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return m.Add(o), nil
+}
+
+// CheckedSub is Sub returning ErrCurrencyMismatch rather than panic.
+func (m Money) CheckedSub(o Money) (Money, error) {
+	if !m.SameCurrency(o) {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return m.Sub(o), nil
+}
+
+func (m Money) Neg() Money {
+	return New(-m.Amount, m.Currency)
+}
+
+// Mul returns m times a whole quantity, which needs no rounding.
+func (m Money) Mul(quantity int) Money {
+	return New(m.Amount*int64(quantity), m.Currency)
+}
+
+// Scale returns m times factor, rounded to the minor unit.
+func (m Money) Scale(factor float64, r Rounding) Money {
+	x := new(big.Rat).SetInt64(m.Amount)
+	return New(r.round(x.Mul(x, decimal(factor))), m.Currency)
+}
+
+// Div returns m divided by divisor, rounded to the minor unit. It panics
+// if divisor is zero.
+func (m Money) Div(divisor float64, r Rounding) Money {
+	x := new(big.Rat).SetInt64(m.Amount)
+	return New(r.round(x.Quo(x, decimal(divisor))), m.Currency)
+}
+
+// Percent returns percent per cent of m, rounded to the minor unit.
+func (m Money) Percent(percent float64, r Rounding) Money {
+	x := new(big.Rat).SetInt64(m.Amount)
+	x.Mul(x, decimal(percent))
+	return New(r.round(x.Quo(x, big.NewRat(100, 1))), m.Currency)
+}
+
+// Cmp returns -1, 0 or +1 as m is less than, equal to or more than o.
+func (m Money) Cmp(o Money) int {
+	m.currencyWith(o)
+	switch {
+	case m.Amount < o.Amount:
+		return -1
+	case m.Amount > o.Amount:
+		return 1
+	}
+	return 0
+}
+
+func (m Money) IsZero() bool     { return m.Amount == 0 }
+func (m Money) IsPositive() bool { return m.Amount > 0 }
+func (m Money) IsNegative() bool { return m.Amount < 0 }
+
+// Min returns the smaller of two amounts in the same currency.
+func Min(a, b Money) Money {
+	if b.Cmp(a) < 0 {
+		return b
+	}
+	return a
+}
+
+// Decimal formats the amount without its currency, e.g. "19.99".
+func (m Money) Decimal() string {
+	exp := m.Currency.Exponent()
+	digits := strconv.FormatInt(m.Amount, 10)
+	sign := ""
+	if m.Amount < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	if exp == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+}
+
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Decimal()
+	}
+	return m.Decimal() + " " + string(m.Currency)
+}
+
+// moneyJSON is how amounts are written in JSON: the decimal amount as an
+// exact number, e.g. {"amount": 19.99, "currency": "EUR"}.
+type moneyJSON struct {
+	Amount   json.Number `json:"amount"`
+	Currency Currency    `json:"currency"`
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: json.Number(m.Decimal()), Currency: m.Currency})
+}
+
+// UnmarshalJSON reads the amount as a number or a string. Amounts other
+// than zero need a currency.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Amount   json.RawMessage `json:"amount"`
+		Currency string          `json:"currency"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	amount := strings.Trim(string(v.Amount), `"`)
+	if amount == "" || amount == "null" {
+		amount = "0"
+	}
+	var currency Currency
+	if v.Currency != "" {
+		var err error
+		if currency, err = ParseCurrency(v.Currency); err != nil {
+			return err
+		}
+	}
+	parsed, err := Parse(amount, currency)
+	if err != nil {
+		return err
+	}
+	if currency == "" && !parsed.IsZero() {
+		return ErrInvalidCurrency
+	}
+	*m = parsed
+	return nil
+}
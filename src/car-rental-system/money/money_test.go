@@ -0,0 +1,199 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseCurrency(t *testing.T) {
+	tests := []struct {
+		code    string
+		want    Currency
+		wantErr error
+	}{
+		{"EUR", EUR, nil},
+		{" usd ", USD, nil},
+		{"eu", "", ErrInvalidCurrency},
+		{"EURO", "", ErrInvalidCurrency},
+		{"E1R", "", ErrInvalidCurrency},
+		{"", "", ErrInvalidCurrency},
+	}
+	for _, tt := range tests {
+		got, err := ParseCurrency(tt.code)
+		if got != tt.want || !errors.Is(err, tt.wantErr) {
+			t.Errorf("ParseCurrency(%q) = %q, %v; want %q, %v", tt.code, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency Currency
+		want     Money
+		wantErr  error
+	}{
+		{"19.99", EUR, New(1999, EUR), nil},
+		{"19.9", EUR, New(1990, EUR), nil},
+		{"20", EUR, New(2000, EUR), nil},
+		{"-0.05", USD, New(-5, USD), nil},
+		{" 7.50 ", GBP, New(750, GBP), nil},
+		{"1500", "JPY", New(1500, "JPY"), nil},
+		{"1.234", "KWD", New(1234, "KWD"), nil},
+		{"19.999", EUR, Money{}, ErrInvalidAmount},
+		{"15.5", "JPY", Money{}, ErrInvalidAmount},
+		{"1e3", EUR, Money{}, ErrInvalidAmount},
+		{"1/2", EUR, Money{}, ErrInvalidAmount},
+		{"abc", EUR, Money{}, ErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.amount, tt.currency)
+		if got != tt.want || !errors.Is(err, tt.wantErr) {
+			t.Errorf("Parse(%q, %s) = %v, %v; want %v, %v", tt.amount, tt.currency, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		m    Money
+		want string
+	}{
+		{New(1999, EUR), "19.99 EUR"},
+		{New(5, USD), "0.05 USD"},
+		{New(-5, USD), "-0.05 USD"},
+		{New(-1234, USD), "-12.34 USD"},
+		{New(0, GBP), "0.00 GBP"},
+		{New(1500, "JPY"), "1500 JPY"},
+		{New(1234, "KWD"), "1.234 KWD"},
+		{Money{}, "0.00"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.String(); got != tt.want {
+			t.Errorf("%#v.String() = %q, want %q", tt.m, got, tt.want)
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Money
+		want Money
+	}{
+		{"add", New(1999, EUR).Add(New(1, EUR)), New(2000, EUR)},
+		{"sub", New(1000, EUR).Sub(New(2500, EUR)), New(-1500, EUR)},
+		{"zero starts a sum", Money{}.Add(New(500, EUR)), New(500, EUR)},
+		{"zero adds to anything", New(500, EUR).Sub(Money{}), New(500, EUR)},
+		{"neg", New(500, EUR).Neg(), New(-500, EUR)},
+		{"mul", New(1999, EUR).Mul(3), New(5997, EUR)},
+		{"min", Min(New(500, EUR), New(300, EUR)), New(300, EUR)},
+		{"min of equals", Min(New(500, EUR), New(500, EUR)), New(500, EUR)},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestRounding(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Money
+		want Money
+	}{
+		{"scale half up", New(1005, EUR).Scale(0.5, HalfUp), New(503, EUR)},
+		{"scale half even down", New(1005, EUR).Scale(0.5, HalfEven), New(502, EUR)},
+		{"scale half even up", New(1015, EUR).Scale(0.5, HalfEven), New(508, EUR)},
+		{"scale down", New(1009, EUR).Scale(0.5, Down), New(504, EUR)},
+		{"scale up", New(1001, EUR).Scale(0.5, Up), New(501, EUR)},
+		{"negative half up goes away from zero", New(-1005, EUR).Scale(0.5, HalfUp), New(-503, EUR)},
+		{"negative down goes toward zero", New(-1009, EUR).Scale(0.5, Down), New(-504, EUR)},
+		{"zero rounding is half up", New(1005, EUR).Scale(0.5, ""), New(503, EUR)},
+		{"decimal factor is exact", New(100, EUR).Scale(1.17, HalfUp), New(117, EUR)},
+		{"div", New(11900, EUR).Div(1.19, HalfUp), New(10000, EUR)},
+		{"div thirds", New(1000, EUR).Div(3, HalfUp), New(333, EUR)},
+		{"percent", New(12345, EUR).Percent(10, HalfUp), New(1235, EUR)},
+		{"percent half even", New(12345, EUR).Percent(10, HalfEven), New(1234, EUR)},
+		{"fractional percent", New(10000, EUR).Percent(7.5, HalfUp), New(750, EUR)},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestSameCurrency(t *testing.T) {
+	tests := []struct {
+		a, b Money
+		want bool
+	}{
+		{New(1, EUR), New(2, EUR), true},
+		{New(1, EUR), New(2, USD), false},
+		{Money{}, New(2, USD), true},
+		{New(1, EUR), Money{}, true},
+		{New(0, EUR), New(0, USD), false},
+		{New(5, ""), New(2, USD), false},
+	}
+	for _, tt := range tests {
+		if got := tt.a.SameCurrency(tt.b); got != tt.want {
+			t.Errorf("%v.SameCurrency(%v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCheckedArithmetic(t *testing.T) {
+	if got, err := New(1999, EUR).CheckedAdd(New(1, EUR)); err != nil || got != New(2000, EUR) {
+		t.Errorf("checked add = %v, %v; want 20.00 EUR", got, err)
+	}
+	if got, err := (Money{}).CheckedSub(New(500, EUR)); err != nil || got != New(-500, EUR) {
+		t.Errorf("checked sub from zero = %v, %v; want -5.00 EUR", got, err)
+	}
+	if _, err := New(1, EUR).CheckedAdd(New(1, USD)); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("checked add of EUR and USD: got %v, want %v", err, ErrCurrencyMismatch)
+	}
+	if _, err := New(1, EUR).CheckedSub(New(1, USD)); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("checked sub of EUR and USD: got %v, want %v", err, ErrCurrencyMismatch)
+	}
+}
+
+func TestCombiningCurrenciesPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("adding EUR to USD did not panic")
+		}
+	}()
+	New(1, EUR).Add(New(1, USD))
+}
+
+func TestJSON(t *testing.T) {
+	tests := []struct {
+		m    Money
+		json string
+	}{
+		{New(1999, EUR), `{"amount":19.99,"currency":"EUR"}`},
+		{New(-5, USD), `{"amount":-0.05,"currency":"USD"}`},
+		{New(1500, "JPY"), `{"amount":1500,"currency":"JPY"}`},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.m)
+		if err != nil || string(data) != tt.json {
+			t.Errorf("Marshal(%v) = %s, %v; want %s", tt.m, data, err, tt.json)
+		}
+		var back Money
+		if err := json.Unmarshal(data, &back); err != nil || back != tt.m {
+			t.Errorf("Unmarshal(%s) = %v, %v; want %v", data, back, err, tt.m)
+		}
+	}
+
+	var m Money
+	if err := json.Unmarshal([]byte(`{"amount":"12.50","currency":"gbp"}`), &m); err != nil || m != New(1250, GBP) {
+		t.Errorf("amount as a string: got %v, %v", m, err)
+	}
+	if err := json.Unmarshal([]byte(`{"amount":12.5}`), &m); err == nil {
+		t.Error("amount without a currency was accepted")
+	}
+}
@@ -0,0 +1,120 @@
+package payments
+
+import (
+	"car-rental-system/money"
+	"fmt"
+	"sync"
+)
+
+// DeclinedMethod is a payment method the fake gateway always declines.
+const DeclinedMethod = "card_declined"
+
+// FakeGateway is a deterministic in-memory provider for tests and local
+// runs. References are numbered in the order authorizations are made.
+type FakeGateway struct {
+	mu      sync.Mutex
+	next    int
+	charges map[string]*fakeCharge
+}
+
+type fakeCharge struct {
+	authorized money.Money
+	captured   money.Money
+	refunded   money.Money
+	voided     bool
+}
+
+func NewFakeGateway() *FakeGateway {
+	return &FakeGateway{charges: make(map[string]*fakeCharge)}
+}
+
+func (g *FakeGateway) Authorize(amount money.Money, method string) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !amount.IsPositive() || amount.Currency == "" {
+		return "", ErrInvalidAmount
+	}
+	if method == DeclinedMethod {
+		return "", ErrDeclined
+	}
+
+	g.next++
+	reference := fmt.Sprintf("fake_%06d", g.next)
+	g.charges[reference] = &fakeCharge{authorized: amount, captured: money.New(0, amount.Currency), refunded: money.New(0, amount.Currency)}
+	return reference, nil
+}
+
+func (g *FakeGateway) charge(reference string) (*fakeCharge, error) {
+	charge, exists := g.charges[reference]
+	if !exists {
+		return nil, ErrUnknownReference
+	}
+	return charge, nil
+}
+
+// checkCurrency makes sure amount is in the currency charge was authorized
+// in, before it is compared with the charge's amounts.
+func (c *fakeCharge) checkCurrency(amount money.Money) error {
+	if amount.Currency != c.authorized.Currency {
+		return ErrCurrencyMismatch
+	}
+	return nil
+}
+
+func (g *FakeGateway) Capture(reference string, amount money.Money) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	charge, err := g.charge(reference)
+	if err != nil {
+		return err
+	}
+	if charge.voided || charge.captured.IsPositive() {
+		return ErrInvalidState
+	}
+	if err := charge.checkCurrency(amount); err != nil {
+		return err
+	}
+	if !amount.IsPositive() || amount.Cmp(charge.authorized) > 0 {
+		return ErrInvalidAmount
+	}
+	charge.captured = amount
+	return nil
+}
+
+func (g *FakeGateway) Refund(reference string, amount money.Money) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	charge, err := g.charge(reference)
+	if err != nil {
+		return err
+	}
+	if charge.captured.IsZero() {
+		return ErrInvalidState
+	}
+	if err := charge.checkCurrency(amount); err != nil {
+		return err
+	}
+	if !amount.IsPositive() || charge.refunded.Add(amount).Cmp(charge.captured) > 0 {
+		return ErrInvalidAmount
+	}
+	charge.refunded = charge.refunded.Add(amount)
+	return nil
+}
+
+func (g *FakeGateway) Void(reference string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	charge, err := g.charge(reference)
+	if err != nil {
+		return err
+	}
+	if charge.voided || charge.captured.IsPositive() {
+		return ErrInvalidState
+	}
+	charge.voided = true
+	return nil
+}
@@ -0,0 +1,29 @@
+package payments
+
+import (
+	"car-rental-system/money"
+	"errors"
+)
+
+var (
+	ErrDeclined         = errors.New("payment declined")
+	ErrUnknownReference = errors.New("unknown payment reference")
+	ErrInvalidAmount    = errors.New("invalid payment amount")
+	ErrCurrencyMismatch = errors.New("amount is not in the currency of the authorization")
+	ErrInvalidState     = errors.New("payment is not in a state that allows this operation")
+)
+
+// Gateway is a payment provider. Money is first authorized (held on the
+// customer's card), then captured, and captured money can be refunded.
+// Authorizations that will not be captured are voided.
+type Gateway interface {
+	// Authorize holds amount and returns the provider's reference for it.
+	// Captures and refunds are in the currency that was authorized.
+	Authorize(amount money.Money, method string) (string, error)
+	// Capture takes up to the authorized amount.
+	Capture(reference string, amount money.Money) error
+	// Refund returns up to the captured amount.
+	Refund(reference string, amount money.Money) error
+	// Void releases an authorization that was never captured.
+	Void(reference string) error
+}
@@ -0,0 +1,135 @@
+package pricing
+
+import (
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"time"
+)
+
+// rounding is how rules round the percentages they charge or take off to
+// the currency's minor unit.
+const rounding = money.HalfUp
+
+// Request describes the rental being priced. The rental is charged in the
+// currency of the car's daily rate; fees, extras and discounts set in
+// other currencies are converted with Exchange.
+type Request struct {
+	Car      models.Car
+	Customer models.Customer
+	Start    time.Time
+	End      time.Time
+	// Days is the number of billable days, already adjusted for grace
+	// periods by the caller.
+	Days int
+	// PickupBranchID and DropoffBranchID are zero when the car is not
+	// tied to a branch.
+	PickupBranchID  int
+	DropoffBranchID int
+	// Extras are the add-ons booked with the car. Only engines with the
+	// ExtraCharges rule charge for them.
+	Extras []models.ReservationExtra
+	// LoyaltyTier is the customer's tier, for rules that give members a
+	// better price. LoyaltyDiscount is the value of the points redeemed,
+	// taken off by the LoyaltyRedemption rule.
+	LoyaltyTier     models.LoyaltyTier
+	LoyaltyDiscount money.Money
+	// Promo is the promo code booked with, applied by the PromoDiscount
+	// rule.
+	Promo    *models.AppliedPromo
+	Exchange money.Exchange
+}
+
+// Currency is what the rental is charged in.
+func (r Request) Currency() money.Currency {
+	return r.Car.RentalPricePerDay.Currency
+}
+
+// RentalDates returns the calendar date of each billable day.
+func (r Request) RentalDates() []time.Time {
+	dates := make([]time.Time, r.Days)
+	for i := range dates {
+		dates[i] = r.Start.AddDate(0, 0, i)
+	}
+	return dates
+}
+
+// Quote is the itemized price of a rental.
+type Quote struct {
+	Lines []models.PriceLine
+	Total money.Money
+	// err is the first amount that could not be converted.
+	err error
+}
+
+// Subtotal sums the lines added so far.
+func (q *Quote) Subtotal() money.Money {
+	var total money.Money
+	for _, line := range q.Lines {
+		total = total.Add(line.Amount)
+	}
+	return total
+}
+
+// add adds quantity units of unitPrice, leaving out lines that come to
+// nothing.
+func (q *Quote) add(code, description string, quantity int, unitPrice money.Money) {
+	line := models.PriceLine{
+		Code:        code,
+		Description: description,
+		Quantity:    quantity,
+		UnitPrice:   unitPrice,
+		Amount:      unitPrice.Mul(quantity),
+	}
+	if line.Amount.IsZero() {
+		return
+	}
+	q.Lines = append(q.Lines, line)
+}
+
+// convert converts an amount set in another currency into the rental's.
+// A failed conversion fails the whole quote and counts as zero meanwhile.
+func (q *Quote) convert(req Request, amount money.Money) money.Money {
+	converted, err := req.Exchange.Convert(amount, req.Currency())
+	if err != nil {
+		if q.err == nil {
+			q.err = err
+		}
+		return money.New(0, req.Currency())
+	}
+	return converted
+}
+
+// Rule adds its lines to a quote. Rules run in the order they are given to
+// the engine, so discounts should come after the charges they reduce.
+type Rule interface {
+	Apply(req Request, quote *Quote)
+}
+
+// Engine prices rentals by running its rules in turn.
+type Engine struct {
+	rules []Rule
+}
+
+func NewEngine(rules ...Rule) *Engine {
+	return &Engine{rules: rules}
+}
+
+// DefaultEngine charges the car's daily rate and the extras booked with
+// it, less any promo code and loyalty points, and nothing else.
+func DefaultEngine() *Engine {
+	return NewEngine(BaseRate{}, ExtraCharges{}, PromoDiscount{}, LoyaltyRedemption{})
+}
+
+// Quote prices the rental. It fails when an amount the rules need cannot
+// be converted into the rental's currency.
+func (e *Engine) Quote(req Request) (Quote, error) {
+	var quote Quote
+	for _, rule := range e.rules {
+		rule.Apply(req, &quote)
+	}
+	if quote.err != nil {
+		return Quote{}, quote.err
+	}
+	quote.Total = money.New(quote.Subtotal().Amount, req.Currency())
+	return quote, nil
+}
@@ -0,0 +1,260 @@
+package pricing
+
+import (
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"errors"
+	"testing"
+	"time"
+)
+
+func usd(amount int64) money.Money { return money.New(amount, money.USD) }
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// line is the part of a price line the tests check.
+type line struct {
+	code     string
+	quantity int
+	amount   money.Money
+}
+
+func TestEngineQuote(t *testing.T) {
+	// Friday to Monday: a Friday, a Saturday and a Sunday.
+	base := Request{
+		Car:      models.Car{Make: "Toyota", Model: "Corolla", RentalPricePerDay: usd(50_00)},
+		Customer: models.Customer{DateOfBirth: date(1990, time.January, 2)},
+		Start:    date(2025, time.March, 7),
+		End:      date(2025, time.March, 10),
+		Days:     3,
+		Exchange: money.Exchange{Rates: money.Rates{{From: money.EUR, To: money.USD, Value: 1.2}}, Rounding: money.HalfEven},
+	}
+	rental := line{"rental_days", 3, usd(150_00)}
+
+	tests := []struct {
+		name    string
+		rules   []Rule
+		edit    func(*Request)
+		want    []line
+		total   money.Money
+		wantErr error
+	}{
+		{
+			name:  "base rate",
+			rules: []Rule{BaseRate{}},
+			want:  []line{rental},
+			total: usd(150_00),
+		},
+		{
+			name:  "weekend surcharge",
+			rules: []Rule{BaseRate{}, WeekendSurcharge{Percent: 20}},
+			want:  []line{rental, {"weekend_surcharge", 2, usd(20_00)}},
+			total: usd(170_00),
+		},
+		{
+			name:  "holiday surcharge",
+			rules: []Rule{BaseRate{}, HolidaySurcharge{Percent: 50, Holidays: []time.Time{date(2025, time.March, 8), date(2025, time.December, 25)}}},
+			want:  []line{rental, {"holiday_surcharge", 1, usd(25_00)}},
+			total: usd(175_00),
+		},
+		{
+			name: "seasonal rates",
+			rules: []Rule{BaseRate{}, SeasonalRates{Seasons: []Season{
+				{Name: "Spring", Start: MonthDay{time.March, 1}, End: MonthDay{time.May, 31}, Multiplier: 1.2},
+				{Name: "Summer", Start: MonthDay{time.June, 1}, End: MonthDay{time.August, 31}, Multiplier: 1.5},
+			}}},
+			want:  []line{rental, {"seasonal_rate", 3, usd(30_00)}},
+			total: usd(180_00),
+		},
+		{
+			name:  "season over the new year",
+			rules: []Rule{BaseRate{}, SeasonalRates{Seasons: []Season{{Name: "Winter", Start: MonthDay{time.December, 31}, End: MonthDay{time.January, 1}, Multiplier: 0.8}}}},
+			edit:  func(r *Request) { r.Start = date(2024, time.December, 30) },
+			want:  []line{rental, {"seasonal_rate", 2, usd(-20_00)}},
+			total: usd(130_00),
+		},
+		{
+			name:  "best long rental discount",
+			rules: []Rule{BaseRate{}, LongRentalDiscount{Tiers: []DiscountTier{{Name: "Weekly", MinDays: 7, Percent: 15}, {Name: "Short", MinDays: 3, Percent: 5}, {Name: "Any", MinDays: 1, Percent: 2}}}},
+			want:  []line{rental, {"long_rental_discount", 1, usd(-7_50)}},
+			total: usd(142_50),
+		},
+		{
+			name:  "young driver fee converted",
+			rules: []Rule{BaseRate{}, YoungDriverFee{MinAge: 25, FeePerDay: money.New(10_00, money.EUR)}},
+			edit:  func(r *Request) { r.Customer.DateOfBirth = date(2000, time.March, 8) },
+			want:  []line{rental, {"young_driver_fee", 3, usd(36_00)}},
+			total: usd(186_00),
+		},
+		{
+			name:  "young driver fee waived for the tier",
+			rules: []Rule{BaseRate{}, YoungDriverFee{MinAge: 25, FeePerDay: usd(10_00), WaivedTiers: []models.LoyaltyTier{models.TierGold}}},
+			edit: func(r *Request) {
+				r.Customer.DateOfBirth = date(2003, time.January, 1)
+				r.LoyaltyTier = models.TierGold
+			},
+			want:  []line{rental},
+			total: usd(150_00),
+		},
+		{
+			name:  "driver turns the age on the first day",
+			rules: []Rule{BaseRate{}, YoungDriverFee{MinAge: 25, FeePerDay: usd(10_00)}},
+			edit:  func(r *Request) { r.Customer.DateOfBirth = date(2000, time.March, 7) },
+			want:  []line{rental},
+			total: usd(150_00),
+		},
+		{
+			name:    "fee without an exchange rate",
+			rules:   []Rule{BaseRate{}, YoungDriverFee{MinAge: 25, FeePerDay: money.New(10_00, money.GBP)}},
+			edit:    func(r *Request) { r.Customer.DateOfBirth = date(2003, time.January, 1) },
+			wantErr: money.ErrNoRate,
+		},
+		{
+			name:  "one-way route fee",
+			rules: []Rule{BaseRate{}, OneWayFee{Fee: usd(30_00), Routes: map[Route]money.Money{{From: 1, To: 2}: usd(20_00)}}},
+			edit:  func(r *Request) { r.PickupBranchID, r.DropoffBranchID = 1, 2 },
+			want:  []line{rental, {"one_way_fee", 1, usd(20_00)}},
+			total: usd(170_00),
+		},
+		{
+			name:  "one-way default fee",
+			rules: []Rule{BaseRate{}, OneWayFee{Fee: usd(30_00), Routes: map[Route]money.Money{{From: 1, To: 2}: usd(20_00)}}},
+			edit:  func(r *Request) { r.PickupBranchID, r.DropoffBranchID = 2, 1 },
+			want:  []line{rental, {"one_way_fee", 1, usd(30_00)}},
+			total: usd(180_00),
+		},
+		{
+			name:  "round trip has no one-way fee",
+			rules: []Rule{BaseRate{}, OneWayFee{Fee: usd(30_00)}},
+			edit:  func(r *Request) { r.PickupBranchID, r.DropoffBranchID = 1, 1 },
+			want:  []line{rental},
+			total: usd(150_00),
+		},
+		{
+			name:  "extras per day and per rental",
+			rules: []Rule{BaseRate{}, ExtraCharges{}},
+			edit: func(r *Request) {
+				r.Extras = []models.ReservationExtra{
+					{Code: "child_seat", Name: "Child seat", Pricing: models.ExtraPerDay, Price: usd(8_00), Quantity: 2},
+					{Code: "gps", Name: "GPS", Pricing: models.ExtraPerRental, Price: money.New(10_00, money.EUR), Quantity: 1},
+				}
+			},
+			want:  []line{rental, {"extra_child_seat", 6, usd(48_00)}, {"extra_gps", 1, usd(12_00)}},
+			total: usd(210_00),
+		},
+		{
+			name:  "percent promo",
+			rules: []Rule{BaseRate{}, WeekendSurcharge{Percent: 20}, PromoDiscount{}},
+			edit: func(r *Request) {
+				r.Promo = &models.AppliedPromo{Code: "SPRING10", Kind: models.DiscountPercent, Value: 10}
+			},
+			want:  []line{rental, {"weekend_surcharge", 2, usd(20_00)}, {"promo_discount", 1, usd(-17_00)}},
+			total: usd(153_00),
+		},
+		{
+			name:  "fixed promo capped at the price",
+			rules: []Rule{BaseRate{}, PromoDiscount{}},
+			edit: func(r *Request) {
+				r.Promo = &models.AppliedPromo{Code: "BIG", Kind: models.DiscountFixed, Amount: usd(200_00)}
+			},
+			want:  []line{rental, {"promo_discount", 1, usd(-150_00)}},
+			total: usd(0),
+		},
+		{
+			name:  "promo stacks on other discounts",
+			rules: []Rule{BaseRate{}, LongRentalDiscount{Tiers: []DiscountTier{{Name: "Short", MinDays: 3, Percent: 10}}}, PromoDiscount{}},
+			edit: func(r *Request) {
+				r.Promo = &models.AppliedPromo{Code: "TEN", Kind: models.DiscountFixed, Amount: usd(10_00)}
+			},
+			want:  []line{rental, {"long_rental_discount", 1, usd(-15_00)}, {"promo_discount", 1, usd(-10_00)}},
+			total: usd(125_00),
+		},
+		{
+			name:  "non-stackable promo replaces other discounts",
+			rules: []Rule{BaseRate{}, LongRentalDiscount{Tiers: []DiscountTier{{Name: "Short", MinDays: 3, Percent: 10}}}, PromoDiscount{}},
+			edit: func(r *Request) {
+				r.Promo = &models.AppliedPromo{Code: "TEN", Kind: models.DiscountFixed, Amount: usd(10_00), NonStackable: true}
+			},
+			want:  []line{rental, {"promo_discount", 1, usd(-10_00)}},
+			total: usd(140_00),
+		},
+		{
+			name:  "loyalty points",
+			rules: []Rule{BaseRate{}, LoyaltyRedemption{}},
+			edit:  func(r *Request) { r.LoyaltyDiscount = money.New(5_00, money.EUR) },
+			want:  []line{rental, {"loyalty_redemption", 1, usd(-6_00)}},
+			total: usd(144_00),
+		},
+		{
+			name:  "loyalty points never go below zero",
+			rules: []Rule{BaseRate{}, PromoDiscount{}, LoyaltyRedemption{}},
+			edit: func(r *Request) {
+				r.Promo = &models.AppliedPromo{Code: "HALF", Kind: models.DiscountPercent, Value: 50}
+				r.LoyaltyDiscount = usd(100_00)
+			},
+			want:  []line{rental, {"promo_discount", 1, usd(-75_00)}, {"loyalty_redemption", 1, usd(-75_00)}},
+			total: usd(0),
+		},
+		{
+			name:  "default engine",
+			rules: DefaultEngine().rules,
+			edit: func(r *Request) {
+				r.Extras = []models.ReservationExtra{{Code: "cdw", Name: "Collision damage waiver", Pricing: models.ExtraPerDay, Price: usd(18_00), Quantity: 1}}
+				r.Promo = &models.AppliedPromo{Code: "SPRING10", Kind: models.DiscountPercent, Value: 10}
+				r.LoyaltyDiscount = usd(4_00)
+			},
+			want:  []line{rental, {"extra_cdw", 3, usd(54_00)}, {"promo_discount", 1, usd(-20_40)}, {"loyalty_redemption", 1, usd(-4_00)}},
+			total: usd(179_60),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := base
+			if tt.edit != nil {
+				tt.edit(&req)
+			}
+			quote, err := NewEngine(tt.rules...).Quote(req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Quote: got %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			var got []line
+			for _, l := range quote.Lines {
+				got = append(got, line{l.Code, l.Quantity, l.Amount})
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("lines = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("line %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+			if quote.Total != tt.total {
+				t.Errorf("total = %v, want %v", quote.Total, tt.total)
+			}
+		})
+	}
+}
+
+func TestAgeOn(t *testing.T) {
+	birth := date(2000, time.March, 8)
+	tests := []struct {
+		on   time.Time
+		want int
+	}{
+		{date(2025, time.March, 7), 24},
+		{date(2025, time.March, 8), 25},
+		{date(2025, time.December, 31), 25},
+		{date(2000, time.March, 8), 0},
+	}
+	for _, tt := range tests {
+		if got := AgeOn(birth, tt.on); got != tt.want {
+			t.Errorf("AgeOn(%s) = %d, want %d", tt.on.Format(time.DateOnly), got, tt.want)
+		}
+	}
+}
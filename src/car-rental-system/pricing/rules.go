@@ -0,0 +1,250 @@
+package pricing
+
+import (
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// BaseRate charges the car's daily rate for every billable day.
+type BaseRate struct{}
+
+func (BaseRate) Apply(req Request, quote *Quote) {
+	quote.add("rental_days", fmt.Sprintf("%s %s rental", req.Car.Make, req.Car.Model), req.Days, req.Car.RentalPricePerDay)
+}
+
+// WeekendSurcharge adds a percentage of the daily rate for each rental day
+// falling on a Saturday or Sunday.
+type WeekendSurcharge struct {
+	Percent float64
+}
+
+func (w WeekendSurcharge) Apply(req Request, quote *Quote) {
+	count := 0
+	for _, date := range req.RentalDates() {
+		if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+			count++
+		}
+	}
+	unit := req.Car.RentalPricePerDay.Percent(w.Percent, rounding)
+	quote.add("weekend_surcharge", fmt.Sprintf("Weekend surcharge (%g%%)", w.Percent), count, unit)
+}
+
+// HolidaySurcharge adds a percentage of the daily rate for each rental day
+// that is one of the listed holidays.
+type HolidaySurcharge struct {
+	Percent  float64
+	Holidays []time.Time
+}
+
+func (h HolidaySurcharge) Apply(req Request, quote *Quote) {
+	holidays := make(map[string]bool, len(h.Holidays))
+	for _, holiday := range h.Holidays {
+		holidays[holiday.Format(time.DateOnly)] = true
+	}
+
+	count := 0
+	for _, date := range req.RentalDates() {
+		if holidays[date.Format(time.DateOnly)] {
+			count++
+		}
+	}
+	unit := req.Car.RentalPricePerDay.Percent(h.Percent, rounding)
+	quote.add("holiday_surcharge", fmt.Sprintf("Holiday surcharge (%g%%)", h.Percent), count, unit)
+}
+
+// MonthDay is a recurring day of the year.
+type MonthDay struct {
+	Month time.Month
+	Day   int
+}
+
+func (md MonthDay) before(other MonthDay) bool {
+	return md.Month < other.Month || (md.Month == other.Month && md.Day < other.Day)
+}
+
+// Season is an inclusive range of days with its own rate. A season whose
+// End comes before its Start wraps over the new year.
+type Season struct {
+	Name  string
+	Start MonthDay
+	End   MonthDay
+	// Multiplier is applied to the car's daily rate, e.g. 1.25 for high
+	// season or 0.8 for low season.
+	Multiplier float64
+}
+
+func (s Season) contains(date time.Time) bool {
+	md := MonthDay{Month: date.Month(), Day: date.Day()}
+	if s.End.before(s.Start) {
+		return !md.before(s.Start) || !s.End.before(md)
+	}
+	return !md.before(s.Start) && !s.End.before(md)
+}
+
+// SeasonalRates adjusts the daily rate for rental days inside a season.
+// The first matching season wins.
+type SeasonalRates struct {
+	Seasons []Season
+}
+
+func (sr SeasonalRates) Apply(req Request, quote *Quote) {
+	counts := make([]int, len(sr.Seasons))
+	for _, date := range req.RentalDates() {
+		for i, season := range sr.Seasons {
+			if season.contains(date) {
+				counts[i]++
+				break
+			}
+		}
+	}
+
+	for i, season := range sr.Seasons {
+		rate := req.Car.RentalPricePerDay
+		unit := rate.Scale(season.Multiplier, rounding).Sub(rate)
+		quote.add("seasonal_rate", fmt.Sprintf("%s rate (x%g)", season.Name, season.Multiplier), counts[i], unit)
+	}
+}
+
+// DiscountTier gives Percent off to rentals of at least MinDays.
+type DiscountTier struct {
+	Name    string
+	MinDays int
+	Percent float64
+}
+
+// LongRentalDiscount takes the best matching tier off everything charged
+// before it, e.g. weekly and monthly rates.
+type LongRentalDiscount struct {
+	Tiers []DiscountTier
+}
+
+func (d LongRentalDiscount) Apply(req Request, quote *Quote) {
+	var best *DiscountTier
+	for i, tier := range d.Tiers {
+		if req.Days >= tier.MinDays && (best == nil || tier.Percent > best.Percent) {
+			best = &d.Tiers[i]
+		}
+	}
+	if best == nil {
+		return
+	}
+
+	discount := quote.Subtotal().Percent(best.Percent, rounding)
+	quote.add("long_rental_discount", fmt.Sprintf("%s discount (%g%%)", best.Name, best.Percent), 1, discount.Neg())
+}
+
+// YoungDriverFee charges a daily fee when the driver is younger than MinAge
+// on the first day of the rental. Customers without a date of birth, and
+// members of the WaivedTiers, are not charged.
+type YoungDriverFee struct {
+	MinAge      int
+	FeePerDay   money.Money
+	WaivedTiers []models.LoyaltyTier
+}
+
+func (y YoungDriverFee) Apply(req Request, quote *Quote) {
+	if req.Customer.DateOfBirth.IsZero() || AgeOn(req.Customer.DateOfBirth, req.Start) >= y.MinAge {
+		return
+	}
+	if slices.Contains(y.WaivedTiers, req.LoyaltyTier) {
+		return
+	}
+
+	quote.add("young_driver_fee", fmt.Sprintf("Young driver fee (under %d)", y.MinAge), req.Days, quote.convert(req, y.FeePerDay))
+}
+
+// AgeOn returns how many full years old someone born on birth is on date.
+func AgeOn(birth, date time.Time) int {
+	age := date.Year() - birth.Year()
+	if date.Month() < birth.Month() || (date.Month() == birth.Month() && date.Day() < birth.Day()) {
+		age--
+	}
+	return age
+}
+
+// Route is a one-way trip between two branches.
+type Route struct {
+	From int
+	To   int
+}
+
+// OneWayFee charges for leaving the car at a different branch than it was
+// picked up from. Routes overrides Fee for particular trips.
+type OneWayFee struct {
+	Fee    money.Money
+	Routes map[Route]money.Money
+}
+
+func (o OneWayFee) Apply(req Request, quote *Quote) {
+	if req.PickupBranchID == 0 || req.DropoffBranchID == 0 || req.PickupBranchID == req.DropoffBranchID {
+		return
+	}
+	fee, ok := o.Routes[Route{From: req.PickupBranchID, To: req.DropoffBranchID}]
+	if !ok {
+		fee = o.Fee
+	}
+
+	quote.add("one_way_fee", "One-way rental fee", 1, quote.convert(req, fee))
+}
+
+// ExtraCharges adds a line for each extra booked with the rental. Extras
+// priced per day count one unit per item and billable day, so two child
+// seats over three days are six units.
+type ExtraCharges struct{}
+
+func (ExtraCharges) Apply(req Request, quote *Quote) {
+	for _, extra := range req.Extras {
+		quantity := extra.Quantity
+		if extra.Pricing == models.ExtraPerDay {
+			quantity *= req.Days
+		}
+		quote.add("extra_"+extra.Code, extra.Name, quantity, quote.convert(req, extra.Price))
+	}
+}
+
+// PromoDiscount takes the promo code's discount off everything charged
+// before it. A non-stackable code first removes the discounts already
+// given, so only the code applies.
+type PromoDiscount struct{}
+
+func (PromoDiscount) Apply(req Request, quote *Quote) {
+	promo := req.Promo
+	if promo == nil {
+		return
+	}
+	if promo.NonStackable {
+		lines := quote.Lines[:0]
+		for _, line := range quote.Lines {
+			if !line.Amount.IsNegative() {
+				lines = append(lines, line)
+			}
+		}
+		quote.Lines = lines
+	}
+
+	description := fmt.Sprintf("Promo code %s", promo.Code)
+	discount := money.Min(quote.convert(req, promo.Amount), quote.Subtotal())
+	if promo.Kind == models.DiscountPercent {
+		description = fmt.Sprintf("Promo code %s (%g%%)", promo.Code, promo.Value)
+		discount = quote.Subtotal().Percent(promo.Value, rounding)
+	}
+	if !discount.IsPositive() {
+		return
+	}
+	quote.add("promo_discount", description, 1, discount.Neg())
+}
+
+// LoyaltyRedemption takes the value of the points redeemed off everything
+// charged before it, never taking the price below zero. It belongs last.
+type LoyaltyRedemption struct{}
+
+func (LoyaltyRedemption) Apply(req Request, quote *Quote) {
+	discount := money.Min(quote.convert(req, req.LoyaltyDiscount), quote.Subtotal())
+	if !discount.IsPositive() {
+		return
+	}
+	quote.add("loyalty_redemption", "Loyalty points redeemed", 1, discount.Neg())
+}
@@ -1,27 +1,614 @@
 package models
 
+import (
+	"car-rental-system/money"
+	"time"
+)
+
 type Car struct {
-	ID              int
-	Make            string
-	Model           string
-	Year            int
-	LicensePlate    string
-	RentalPricePerDay float64
-	IsAvailable     bool
+	ID           int    `json:"id"`
+	Make         string `json:"make"`
+	Model        string `json:"model"`
+	Year         int    `json:"year"`
+	LicensePlate string `json:"licensePlate"`
+	// RentalPricePerDay is in the currency the car is listed in.
+	RentalPricePerDay money.Money `json:"rentalPricePerDay" gorm:"embedded;embeddedPrefix:rental_price_per_day_"`
+	// Odometer is the car's mileage in kilometres as of its last return.
+	Odometer int `json:"odometer"`
+	// Class is the code of the car's VehicleClass. Classes also group cars
+	// that share driver requirements.
+	Class  string    `json:"class" gorm:"index"`
+	Status CarStatus `json:"status"`
+	// BranchID is where the car is parked between rentals. Zero means the
+	// car is not tied to a branch.
+	BranchID int `json:"branchId" gorm:"index"`
+	// CancellationPolicy applies to new bookings of this car. A zero policy
+	// falls back to the rental system's default.
+	CancellationPolicy CancellationPolicy `json:"cancellationPolicy" gorm:"embedded;embeddedPrefix:cancellation_"`
+}
+
+// VehicleClass is a category of car customers can book without picking a
+// particular car, such as economy or SUV.
+type VehicleClass struct {
+	Code string `json:"code" gorm:"primaryKey;size:32"`
+	Name string `json:"name"`
+	// Rank orders the classes from cheapest up; a sold-out class is
+	// upgraded to the class with the next higher rank.
+	Rank            int          `json:"rank"`
+	DailyRate       money.Money  `json:"dailyRate" gorm:"embedded;embeddedPrefix:daily_rate_"`
+	Seats           int          `json:"seats"`
+	Transmission    Transmission `json:"transmission"`
+	FuelType        FuelType     `json:"fuelType"`
+	LuggageCapacity int          `json:"luggageCapacity"` // suitcases
+}
+
+type Transmission string
+
+const (
+	TransmissionManual    Transmission = "manual"
+	TransmissionAutomatic Transmission = "automatic"
+)
+
+type FuelType string
+
+const (
+	FuelPetrol   FuelType = "petrol"
+	FuelDiesel   FuelType = "diesel"
+	FuelHybrid   FuelType = "hybrid"
+	FuelElectric FuelType = "electric"
+)
+
+// Branch is a location where cars are picked up and dropped off.
+type Branch struct {
+	ID      int     `json:"id"`
+	Name    string  `json:"name"`
+	Address Address `json:"address" gorm:"embedded;embeddedPrefix:address_"`
+	// Currency is what rentals picked up here are charged in. Empty means
+	// the currency of the car's own rate.
+	Currency money.Currency `json:"currency,omitempty" gorm:"size:3"`
+	// OpeningHours lists when the counter is staffed. A branch without any
+	// is open around the clock.
+	OpeningHours []OpeningHours `json:"openingHours" gorm:"serializer:json"`
+}
+
+// OpeningHours is one day's opening time, as "15:04" clock times.
+type OpeningHours struct {
+	Weekday time.Weekday `json:"weekday"`
+	Open    string       `json:"open"`
+	Close   string       `json:"close"`
+}
+
+// IsOpenAt reports whether the branch is staffed at t. A time of exactly
+// midnight stands for the whole day, so only the weekday is checked.
+func (b Branch) IsOpenAt(t time.Time) bool {
+	if len(b.OpeningHours) == 0 {
+		return true
+	}
+	clock := t.Format("15:04")
+	for _, hours := range b.OpeningHours {
+		if hours.Weekday != t.Weekday() {
+			continue
+		}
+		if clock == "00:00" || (clock >= hours.Open && clock < hours.Close) {
+			return true
+		}
+	}
+	return false
+}
+
+// CarStatus is where a car is in its life with the fleet. Only active cars
+// can be booked; maintenance that is planned ahead goes in a
+// MaintenanceWindow instead.
+type CarStatus string
+
+const (
+	CarActive        CarStatus = "active"
+	CarInMaintenance CarStatus = "in_maintenance"
+	CarOutOfService  CarStatus = "out_of_service"
+	CarRetired       CarStatus = "retired"
+)
+
+// Valid reports whether s is one of the known statuses.
+func (s CarStatus) Valid() bool {
+	switch s {
+	case CarActive, CarInMaintenance, CarOutOfService, CarRetired:
+		return true
+	}
+	return false
+}
+
+// Rentable reports whether the car can take new bookings. Cars stored
+// before statuses existed have none and count as active.
+func (c Car) Rentable() bool {
+	return c.Status == "" || c.Status == CarActive
+}
+
+// MaintenanceWindow takes a car off the road for [Start, End).
+type MaintenanceWindow struct {
+	ID        int       `json:"id"`
+	CarID     int       `json:"carId" gorm:"index"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// CancellationPolicy sets what a customer pays when cancelling.
+type CancellationPolicy struct {
+	Name string `json:"name"`
+	// FreeCancellationHours is how long before pickup a reservation can
+	// still be cancelled at no cost.
+	FreeCancellationHours int `json:"freeCancellationHours"`
+	// LateFeePercent of the total price is charged for cancellations inside
+	// that window.
+	LateFeePercent float64 `json:"lateFeePercent"`
+	// NonRefundable rates keep the whole price whenever they are cancelled.
+	NonRefundable bool `json:"nonRefundable"`
+}
+
+type ReservationStatus string
+
+const (
+	ReservationActive ReservationStatus = "active"
+	// ReservationInProgress reservations have had their car picked up.
+	ReservationInProgress ReservationStatus = "in_progress"
+	// ReservationCompleted reservations have had their car returned and
+	// the usage charged.
+	ReservationCompleted ReservationStatus = "completed"
+	ReservationCancelled ReservationStatus = "cancelled"
+)
+
+// Handover records the car's condition when it changes hands at the
+// counter.
+type Handover struct {
+	At       time.Time `json:"at"`
+	BranchID int       `json:"branchId"`
+	// Odometer is in kilometres.
+	Odometer int `json:"odometer"`
+	// EnergyLevel is how full the tank, or the battery of an electric car,
+	// is in percent.
+	EnergyLevel int    `json:"energyLevel"`
+	DamageNotes string `json:"damageNotes,omitempty"`
+}
+
+type Address struct {
+	Street     string `json:"street"`
+	City       string `json:"city"`
+	PostalCode string `json:"postalCode"`
+	Country    string `json:"country"`
 }
 
 type Customer struct {
-	Name           string
-	ContactDetails string
-	DriversLicense string
+	ID             int       `json:"id"`
+	Name           string    `json:"name"`
+	Email          string    `json:"email"`
+	Phone          string    `json:"phone"`
+	Address        Address   `json:"address" gorm:"embedded;embeddedPrefix:address_"`
+	DriversLicense string    `json:"driversLicense" gorm:"uniqueIndex;size:64"`
+	DateOfBirth    time.Time `json:"dateOfBirth"`
+	// LicenseRegion is where the license was issued: an ISO country code,
+	// followed by the state where licenses are issued per state ("US-CA").
+	LicenseRegion string    `json:"licenseRegion"`
+	LicenseExpiry time.Time `json:"licenseExpiry"`
+}
+
+// BlockedLicense is a driver's license that may not be used to rent.
+type BlockedLicense struct {
+	License   string    `json:"license" gorm:"primaryKey;size:64"`
+	Reason    string    `json:"reason"`
+	BlockedAt time.Time `json:"blockedAt"`
+}
+
+// PriceLine is one item of a reservation's price breakdown. Discounts have
+// a negative Amount, which is always Quantity times UnitPrice.
+type PriceLine struct {
+	Code        string      `json:"code"`
+	Description string      `json:"description"`
+	Quantity    int         `json:"quantity"`
+	UnitPrice   money.Money `json:"unitPrice"`
+	Amount      money.Money `json:"amount"`
 }
 
 type Reservation struct {
-	ID         int
-	Customer   Customer
-	CarID      int
-	StartDate  string
-	EndDate    string
-	TotalPrice float64
-	Paid       bool
-}
\ No newline at end of file
+	ID         int               `json:"id"`
+	Status     ReservationStatus `json:"status"`
+	CustomerID int               `json:"customerId" gorm:"index"`
+	// CarID is zero for a class-only reservation until a car is assigned
+	// at pickup.
+	CarID int `json:"carId"`
+	// VehicleClass is the class that was booked. ClassOnly is set when the
+	// customer reserved the class rather than a particular car, and
+	// Upgraded when the car assigned is of a higher class.
+	VehicleClass string    `json:"vehicleClass"`
+	ClassOnly    bool      `json:"classOnly"`
+	Upgraded     bool      `json:"upgraded"`
+	StartDate    time.Time `json:"startDate"`
+	EndDate      time.Time `json:"endDate"`
+	RentalDays   int       `json:"rentalDays"`
+	// PickupBranchID and DropoffBranchID differ for one-way rentals. Both
+	// are zero for cars that are not tied to a branch.
+	PickupBranchID  int `json:"pickupBranchId"`
+	DropoffBranchID int `json:"dropoffBranchId"`
+	// TotalPrice, and every other amount on the reservation, is in the
+	// currency the rental is charged in.
+	TotalPrice money.Money `json:"totalPrice" gorm:"embedded;embeddedPrefix:total_price_"`
+	// PriceBreakdown lists how TotalPrice was reached.
+	PriceBreakdown []PriceLine `json:"priceBreakdown" gorm:"serializer:json"`
+	Paid           bool        `json:"paid"`
+	AmountPaid     money.Money `json:"amountPaid" gorm:"embedded;embeddedPrefix:amount_paid_"`
+	// AmountDue is what is still owed and RefundDue what was paid beyond
+	// what is charged, e.g. after the reservation was shortened.
+	AmountDue money.Money `json:"amountDue" gorm:"embedded;embeddedPrefix:amount_due_"`
+	RefundDue money.Money `json:"refundDue" gorm:"embedded;embeddedPrefix:refund_due_"`
+	// RateDate is the day whose exchange rates convert amounts set in
+	// other currencies, such as fees and extras, into the rental's.
+	RateDate time.Time `json:"rateDate"`
+	// CancellationPolicy is the policy in force when the reservation was
+	// made, so later changes to the car do not affect it.
+	CancellationPolicy CancellationPolicy `json:"cancellationPolicy" gorm:"embedded;embeddedPrefix:cancellation_"`
+	CancellationFee    money.Money        `json:"cancellationFee" gorm:"embedded;embeddedPrefix:cancellation_fee_"`
+	CancelledAt        *time.Time         `json:"cancelledAt,omitempty"`
+	// CheckOut and CheckIn are recorded when the car is picked up and
+	// returned.
+	CheckOut *Handover `json:"checkOut,omitempty" gorm:"serializer:json"`
+	CheckIn  *Handover `json:"checkIn,omitempty" gorm:"serializer:json"`
+	// Extras are the add-ons booked with the car, priced as they were in
+	// the catalogue when they were added.
+	Extras []ReservationExtra `json:"extras" gorm:"serializer:json"`
+	// LoyaltyTier is the customer's tier when they booked; its benefits
+	// apply for the life of the reservation.
+	LoyaltyTier LoyaltyTier `json:"loyaltyTier,omitempty" gorm:"size:16"`
+	// PointsRedeemed were spent on a LoyaltyDiscount off the price.
+	PointsRedeemed  int         `json:"pointsRedeemed,omitempty"`
+	LoyaltyDiscount money.Money `json:"loyaltyDiscount" gorm:"embedded;embeddedPrefix:loyalty_discount_"`
+	// Promo is the promo code the reservation was booked with.
+	Promo *AppliedPromo `json:"promo,omitempty" gorm:"serializer:json"`
+}
+
+// Charged is what the customer owes for the reservation in all: the price
+// as booked, or only the cancellation fee once it is cancelled.
+func (r Reservation) Charged() money.Money {
+	if r.Status == ReservationCancelled {
+		return r.CancellationFee
+	}
+	return r.TotalPrice
+}
+
+type PaymentStatus string
+
+const (
+	PaymentAuthorized        PaymentStatus = "authorized"
+	PaymentCaptured          PaymentStatus = "captured"
+	PaymentPartiallyRefunded PaymentStatus = "partially_refunded"
+	PaymentRefunded          PaymentStatus = "refunded"
+	PaymentVoided            PaymentStatus = "voided"
+)
+
+// Payment is one charge against a reservation. A reservation may be paid
+// in several parts.
+type Payment struct {
+	ID                int           `json:"id"`
+	ReservationID     int           `json:"reservationId" gorm:"index"`
+	Amount            money.Money   `json:"amount" gorm:"embedded;embeddedPrefix:amount_"`
+	RefundedAmount    money.Money   `json:"refundedAmount" gorm:"embedded;embeddedPrefix:refunded_amount_"`
+	Method            string        `json:"method"`
+	ProviderReference string        `json:"providerReference"`
+	Status            PaymentStatus `json:"status"`
+	CreatedAt         time.Time     `json:"createdAt"`
+	UpdatedAt         time.Time     `json:"updatedAt"`
+}
+
+// Captured returns the part of the payment that is currently held.
+func (p Payment) Captured() money.Money {
+	switch p.Status {
+	case PaymentCaptured, PaymentPartiallyRefunded:
+		return p.Amount.Sub(p.RefundedAmount)
+	}
+	return money.New(0, p.Amount.Currency)
+}
+
+// TaxRate is a tax included in the prices charged, e.g. VAT at 20%.
+type TaxRate struct {
+	Name    string  `json:"name"`
+	Percent float64 `json:"percent"`
+}
+
+type InvoiceKind string
+
+const (
+	InvoiceKindInvoice    InvoiceKind = "invoice"
+	InvoiceKindCreditNote InvoiceKind = "credit_note"
+)
+
+type InvoiceStatus string
+
+const (
+	InvoiceUnpaid        InvoiceStatus = "unpaid"
+	InvoicePartiallyPaid InvoiceStatus = "partially_paid"
+	InvoicePaid          InvoiceStatus = "paid"
+	// CreditNoteApplied is the status of every credit note: the credit is
+	// set off against the reservation's invoices straight away.
+	CreditNoteApplied InvoiceStatus = "applied"
+)
+
+// BillingParty is who an invoice is made out to, copied from the customer
+// when the invoice is issued.
+type BillingParty struct {
+	Name    string  `json:"name"`
+	Email   string  `json:"email"`
+	Address Address `json:"address"`
+}
+
+// TaxLine is the part of an invoice's total that is one tax.
+type TaxLine struct {
+	Name    string      `json:"name"`
+	Percent float64     `json:"percent"`
+	Amount  money.Money `json:"amount"`
+}
+
+// Invoice is an invoice or a credit note for a reservation. Invoices are
+// never changed once issued: when a reservation's price changes, the
+// difference is invoiced or credited in a new document. Amounts include
+// tax and are positive for both kinds.
+type Invoice struct {
+	ID     int         `json:"id"`
+	Number string      `json:"number" gorm:"uniqueIndex;size:32"`
+	Kind   InvoiceKind `json:"kind" gorm:"size:16"`
+	// Sequence numbers each kind of document without gaps.
+	Sequence      int          `json:"sequence"`
+	ReservationID int          `json:"reservationId" gorm:"index"`
+	CustomerID    int          `json:"customerId"`
+	BillTo        BillingParty `json:"billTo" gorm:"serializer:json"`
+	IssuedAt      time.Time    `json:"issuedAt" gorm:"index"`
+	// Reason says why the document was issued, e.g. "reservation modified".
+	Reason   string      `json:"reason"`
+	Lines    []PriceLine `json:"lines" gorm:"serializer:json"`
+	Subtotal money.Money `json:"subtotal" gorm:"embedded;embeddedPrefix:subtotal_"`
+	Taxes    []TaxLine   `json:"taxes" gorm:"serializer:json"`
+	TaxTotal money.Money `json:"taxTotal" gorm:"embedded;embeddedPrefix:tax_total_"`
+	Total    money.Money `json:"total" gorm:"embedded;embeddedPrefix:total_"`
+	// AmountSettled and Status are worked out from the reservation's
+	// payments and credit notes whenever the invoice is read.
+	AmountSettled money.Money   `json:"amountSettled" gorm:"-"`
+	Status        InvoiceStatus `json:"status" gorm:"-"`
+}
+
+type WaitlistStatus string
+
+const (
+	WaitlistWaiting WaitlistStatus = "waiting"
+	// WaitlistOffered entries hold the car or a place in the class until
+	// OfferExpiresAt.
+	WaitlistOffered WaitlistStatus = "offered"
+	WaitlistBooked  WaitlistStatus = "booked"
+	// WaitlistExpired entries let their offer run out, or were never
+	// offered anything before the rental was due to start.
+	WaitlistExpired   WaitlistStatus = "expired"
+	WaitlistWithdrawn WaitlistStatus = "withdrawn"
+)
+
+// Open reports whether the entry is still waiting for, or holding, an
+// offer.
+func (s WaitlistStatus) Open() bool {
+	return s == WaitlistWaiting || s == WaitlistOffered
+}
+
+// WaitlistEntry is a customer waiting for a car, or for any car of a
+// vehicle class, to come free over [StartDate, EndDate). Entries are
+// offered capacity in the order they joined.
+type WaitlistEntry struct {
+	ID         int            `json:"id"`
+	Status     WaitlistStatus `json:"status" gorm:"size:16;index"`
+	CustomerID int            `json:"customerId" gorm:"index"`
+	// CarID is zero when the customer waits for VehicleClass instead.
+	CarID        int       `json:"carId"`
+	VehicleClass string    `json:"vehicleClass"`
+	StartDate    time.Time `json:"startDate"`
+	EndDate      time.Time `json:"endDate"`
+	// PickupBranchID is set to where the car is when a car is offered
+	// without one.
+	PickupBranchID  int        `json:"pickupBranchId"`
+	DropoffBranchID int        `json:"dropoffBranchId"`
+	JoinedAt        time.Time  `json:"joinedAt"`
+	OfferedAt       *time.Time `json:"offeredAt,omitempty"`
+	OfferExpiresAt  *time.Time `json:"offerExpiresAt,omitempty"`
+	// PromoCode, RedeemPoints and Extras are asked for with the booking
+	// made when the offer is accepted. An offer is only made when the
+	// extras are free, and keeps them back while it is open.
+	PromoCode    string             `json:"promoCode,omitempty"`
+	RedeemPoints int                `json:"redeemPoints,omitempty"`
+	Extras       []ReservationExtra `json:"extras,omitempty" gorm:"serializer:json"`
+	// ReservationID is the booking made by accepting the offer.
+	ReservationID int `json:"reservationId,omitempty"`
+}
+
+type HoldStatus string
+
+const (
+	HoldActive    HoldStatus = "held"
+	HoldConfirmed HoldStatus = "confirmed"
+	HoldReleased  HoldStatus = "released"
+	HoldExpired   HoldStatus = "expired"
+)
+
+// Hold keeps a car free for a customer over [StartDate, EndDate) while
+// they finish booking. Until it is confirmed into a reservation, released
+// or runs out at ExpiresAt, it blocks the car just as a reservation would.
+type Hold struct {
+	ID         int        `json:"id"`
+	Status     HoldStatus `json:"status" gorm:"size:16;index"`
+	CustomerID int        `json:"customerId" gorm:"index"`
+	CarID      int        `json:"carId"`
+	StartDate  time.Time  `json:"startDate"`
+	EndDate    time.Time  `json:"endDate"`
+	// PickupBranchID and DropoffBranchID are resolved as for a
+	// reservation when the hold is placed.
+	PickupBranchID  int       `json:"pickupBranchId"`
+	DropoffBranchID int       `json:"dropoffBranchId"`
+	CreatedAt       time.Time `json:"createdAt"`
+	ExpiresAt       time.Time `json:"expiresAt"`
+	// PromoCode, RedeemPoints and Extras are checked when the hold is
+	// placed and applied again when it is confirmed. The extras are kept
+	// back for the hold like its car.
+	PromoCode    string             `json:"promoCode,omitempty"`
+	RedeemPoints int                `json:"redeemPoints,omitempty"`
+	Extras       []ReservationExtra `json:"extras,omitempty" gorm:"serializer:json"`
+	// ReservationID is the booking the hold was confirmed into.
+	ReservationID int `json:"reservationId,omitempty"`
+}
+
+type ExtraKind string
+
+const (
+	ExtraEquipment ExtraKind = "equipment"
+	ExtraInsurance ExtraKind = "insurance"
+	ExtraDriver    ExtraKind = "driver"
+)
+
+// ExtraPricing says whether an extra is charged for every billable day or
+// once per rental.
+type ExtraPricing string
+
+const (
+	ExtraPerDay    ExtraPricing = "per_day"
+	ExtraPerRental ExtraPricing = "per_rental"
+)
+
+// Extra is an add-on from the catalogue, such as a child seat, a GPS unit,
+// an additional driver or a collision damage waiver.
+type Extra struct {
+	Code    string       `json:"code" gorm:"primaryKey;size:32"`
+	Name    string       `json:"name"`
+	Kind    ExtraKind    `json:"kind" gorm:"size:16"`
+	Pricing ExtraPricing `json:"pricing" gorm:"size:16"`
+	Price   money.Money  `json:"price" gorm:"embedded;embeddedPrefix:price_"`
+	// MaxPerReservation caps how many one reservation may take. Zero means
+	// one.
+	MaxPerReservation int `json:"maxPerReservation"`
+	// Stocked extras are physical items limited to the units each branch
+	// holds; the others, like insurance, are never sold out.
+	Stocked bool          `json:"stocked"`
+	Stock   []BranchStock `json:"stock" gorm:"serializer:json"`
+}
+
+// BranchStock is how many units of an extra a branch holds. BranchID is
+// zero for the stock kept with cars that are not tied to a branch.
+type BranchStock struct {
+	BranchID int `json:"branchId"`
+	Quantity int `json:"quantity"`
+}
+
+// StockAt returns how many units the branch holds.
+func (e Extra) StockAt(branchID int) int {
+	for _, stock := range e.Stock {
+		if stock.BranchID == branchID {
+			return stock.Quantity
+		}
+	}
+	return 0
+}
+
+// ReservationExtra is an extra booked on a reservation.
+type ReservationExtra struct {
+	Code     string       `json:"code"`
+	Name     string       `json:"name"`
+	Pricing  ExtraPricing `json:"pricing"`
+	Price    money.Money  `json:"price"`
+	Quantity int          `json:"quantity"`
+}
+
+// LoyaltyTier is a customer's membership level. Customers without enough
+// recent rentals for a higher tier are plain members.
+type LoyaltyTier string
+
+const (
+	TierMember LoyaltyTier = "member"
+	TierSilver LoyaltyTier = "silver"
+	TierGold   LoyaltyTier = "gold"
+)
+
+type LoyaltyEntryKind string
+
+const (
+	LoyaltyEarned     LoyaltyEntryKind = "earned"
+	LoyaltyRedeemed   LoyaltyEntryKind = "redeemed"
+	LoyaltyReinstated LoyaltyEntryKind = "reinstated"
+)
+
+// LoyaltyEntry is one movement on a customer's points ledger. Points are
+// negative when redeemed. Earned entries record the Spend they were
+// awarded for, which also counts toward the customer's tier.
+type LoyaltyEntry struct {
+	ID            int              `json:"id"`
+	CustomerID    int              `json:"customerId" gorm:"index"`
+	ReservationID int              `json:"reservationId"`
+	Kind          LoyaltyEntryKind `json:"kind" gorm:"size:16"`
+	Points        int              `json:"points"`
+	Spend         money.Money      `json:"spend" gorm:"embedded;embeddedPrefix:spend_"`
+	At            time.Time        `json:"at"`
+}
+
+type DiscountKind string
+
+const (
+	DiscountPercent DiscountKind = "percent"
+	DiscountFixed   DiscountKind = "fixed"
+)
+
+// PromoCode is a campaign code customers can enter when they book.
+type PromoCode struct {
+	Code        string       `json:"code" gorm:"primaryKey;size:32"`
+	Description string       `json:"description"`
+	Kind        DiscountKind `json:"kind" gorm:"size:16"`
+	// Value is the percentage off for percent discounts and Amount what
+	// fixed discounts take off.
+	Value  float64     `json:"value,omitempty"`
+	Amount money.Money `json:"amount" gorm:"embedded;embeddedPrefix:amount_"`
+	// ValidFrom and ValidUntil bound when the code can be booked with. A
+	// zero time leaves that end open.
+	ValidFrom  time.Time `json:"validFrom"`
+	ValidUntil time.Time `json:"validUntil"`
+	// MinDays is the shortest rental, in billable days, the code is for.
+	MinDays int `json:"minDays"`
+	// Makes and Classes limit the code to those cars. Either may be empty
+	// to allow any.
+	Makes   []string `json:"makes" gorm:"serializer:json"`
+	Classes []string `json:"classes" gorm:"serializer:json"`
+	// MaxUses caps the bookings made with the code and MaxUsesPerCustomer
+	// those of any one customer. Zero means no limit.
+	MaxUses            int `json:"maxUses"`
+	MaxUsesPerCustomer int `json:"maxUsesPerCustomer"`
+	// NonStackable codes replace the automatic discounts, such as
+	// long-rental rates, and cannot be combined with loyalty points.
+	NonStackable bool `json:"nonStackable"`
+}
+
+// AppliedPromo is a promo code as it was when a reservation was booked
+// with it, so later changes to the code do not affect the booking.
+type AppliedPromo struct {
+	Code         string       `json:"code"`
+	Kind         DiscountKind `json:"kind"`
+	Value        float64      `json:"value,omitempty"`
+	Amount       money.Money  `json:"amount"`
+	MinDays      int          `json:"minDays,omitempty"`
+	NonStackable bool         `json:"nonStackable,omitempty"`
+}
+
+// PromoRedemption is one use of a promo code. Cancelling the reservation
+// voids it, giving the use back.
+type PromoRedemption struct {
+	ID            int       `json:"id"`
+	Code          string    `json:"code" gorm:"index;size:32"`
+	CustomerID    int       `json:"customerId"`
+	ReservationID int       `json:"reservationId"`
+	At            time.Time `json:"at"`
+	Voided        bool      `json:"voided"`
+}
+
+// ExchangeRate is an entry of the exchange-rate table. Rates are never
+// changed; a new rate with a later effective date takes over instead.
+type ExchangeRate struct {
+	ID         int `json:"id"`
+	money.Rate `gorm:"embedded"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
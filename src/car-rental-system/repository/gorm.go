@@ -0,0 +1,387 @@
+package repository
+
+import (
+	models "car-rental-system/rental_system_models"
+	"errors"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+// GormRepository persists the rental system through GORM. SQLite is meant
+// for local runs and tests, MySQL for production.
+type GormRepository struct {
+	db *gorm.DB
+}
+
+// OpenSQLite opens (or creates) a SQLite database file. Use ":memory:" for a
+// throwaway database.
+func OpenSQLite(path string) (*GormRepository, error) {
+	return open(sqlite.Open(path))
+}
+
+// OpenMySQL connects to MySQL with a DSN such as
+// "user:pass@tcp(127.0.0.1:3306)/rentals?parseTime=true".
+func OpenMySQL(dsn string) (*GormRepository, error) {
+	return open(mysql.Open(dsn))
+}
+
+func open(dialector gorm.Dialector) (*GormRepository, error) {
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		return nil, err
+	}
+	return NewGormRepository(db)
+}
+
+// NewGormRepository wraps an open connection and migrates the schema.
+func NewGormRepository(db *gorm.DB) (*GormRepository, error) {
+	if err := db.AutoMigrate(&models.VehicleClass{}, &models.Branch{}, &models.Car{}, &models.MaintenanceWindow{}, &models.Customer{}, &models.BlockedLicense{}, &models.Reservation{}, &models.Payment{}, &models.Invoice{}, &models.WaitlistEntry{}, &models.Hold{}, &models.Extra{}, &models.LoyaltyEntry{}, &models.PromoCode{}, &models.PromoRedemption{}, &models.ExchangeRate{}); err != nil {
+		return nil, err
+	}
+	return &GormRepository{db: db}, nil
+}
+
+func translate(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func (r *GormRepository) SaveCar(car *models.Car) error {
+	return r.db.Save(car).Error
+}
+
+func (r *GormRepository) Car(id int) (*models.Car, error) {
+	var car models.Car
+	if err := r.db.First(&car, id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &car, nil
+}
+
+func (r *GormRepository) Cars() ([]models.Car, error) {
+	var cars []models.Car
+	err := r.db.Order("id").Find(&cars).Error
+	return cars, err
+}
+
+func (r *GormRepository) SaveVehicleClass(class *models.VehicleClass) error {
+	return r.db.Save(class).Error
+}
+
+func (r *GormRepository) VehicleClass(code string) (*models.VehicleClass, error) {
+	var class models.VehicleClass
+	if err := r.db.First(&class, "code = ?", code).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &class, nil
+}
+
+func (r *GormRepository) VehicleClasses() ([]models.VehicleClass, error) {
+	var classes []models.VehicleClass
+	// RANK is a reserved word in MySQL, so the column has to be quoted.
+	err := r.db.Order(clause.OrderByColumn{Column: clause.Column{Name: "rank"}}).Order("code").Find(&classes).Error
+	return classes, err
+}
+
+func (r *GormRepository) SaveBranch(branch *models.Branch) error {
+	return r.db.Save(branch).Error
+}
+
+func (r *GormRepository) Branch(id int) (*models.Branch, error) {
+	var branch models.Branch
+	if err := r.db.First(&branch, id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &branch, nil
+}
+
+func (r *GormRepository) Branches() ([]models.Branch, error) {
+	var branches []models.Branch
+	err := r.db.Order("id").Find(&branches).Error
+	return branches, err
+}
+
+func (r *GormRepository) SaveMaintenanceWindow(window *models.MaintenanceWindow) error {
+	return r.db.Save(window).Error
+}
+
+func (r *GormRepository) MaintenanceWindow(id int) (*models.MaintenanceWindow, error) {
+	var window models.MaintenanceWindow
+	if err := r.db.First(&window, id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &window, nil
+}
+
+func (r *GormRepository) MaintenanceWindows() ([]models.MaintenanceWindow, error) {
+	var windows []models.MaintenanceWindow
+	err := r.db.Order("start").Order("id").Find(&windows).Error
+	return windows, err
+}
+
+func (r *GormRepository) MaintenanceWindowsForCar(carID int) ([]models.MaintenanceWindow, error) {
+	var windows []models.MaintenanceWindow
+	err := r.db.Where("car_id = ?", carID).Order("start").Order("id").Find(&windows).Error
+	return windows, err
+}
+
+func (r *GormRepository) DeleteMaintenanceWindow(id int) error {
+	result := r.db.Delete(&models.MaintenanceWindow{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *GormRepository) SaveCustomer(customer *models.Customer) error {
+	return r.db.Save(customer).Error
+}
+
+func (r *GormRepository) Customer(id int) (*models.Customer, error) {
+	var customer models.Customer
+	if err := r.db.First(&customer, id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &customer, nil
+}
+
+func (r *GormRepository) CustomerByLicense(license string) (*models.Customer, error) {
+	var customer models.Customer
+	if err := r.db.First(&customer, "drivers_license = ?", license).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &customer, nil
+}
+
+func (r *GormRepository) ReservationsForCustomer(customerID int) ([]models.Reservation, error) {
+	var reservations []models.Reservation
+	err := r.db.Where("customer_id = ?", customerID).Order("id").Find(&reservations).Error
+	return reservations, err
+}
+
+func (r *GormRepository) SaveBlockedLicense(entry *models.BlockedLicense) error {
+	return r.db.Save(entry).Error
+}
+
+func (r *GormRepository) BlockedLicense(license string) (*models.BlockedLicense, error) {
+	var entry models.BlockedLicense
+	if err := r.db.First(&entry, "license = ?", license).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &entry, nil
+}
+
+func (r *GormRepository) BlockedLicenses() ([]models.BlockedLicense, error) {
+	var entries []models.BlockedLicense
+	err := r.db.Order("license").Find(&entries).Error
+	return entries, err
+}
+
+func (r *GormRepository) DeleteBlockedLicense(license string) error {
+	result := r.db.Delete(&models.BlockedLicense{}, "license = ?", license)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *GormRepository) SaveReservation(res *models.Reservation) error {
+	return r.db.Save(res).Error
+}
+
+func (r *GormRepository) Reservation(id int) (*models.Reservation, error) {
+	var res models.Reservation
+	if err := r.db.First(&res, id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &res, nil
+}
+
+func (r *GormRepository) Reservations() ([]models.Reservation, error) {
+	var reservations []models.Reservation
+	err := r.db.Order("id").Find(&reservations).Error
+	return reservations, err
+}
+
+func (r *GormRepository) DeleteReservation(id int) error {
+	result := r.db.Delete(&models.Reservation{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *GormRepository) SavePayment(payment *models.Payment) error {
+	return r.db.Save(payment).Error
+}
+
+func (r *GormRepository) Payment(id int) (*models.Payment, error) {
+	var payment models.Payment
+	if err := r.db.First(&payment, id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &payment, nil
+}
+
+func (r *GormRepository) PaymentsForReservation(reservationID int) ([]models.Payment, error) {
+	var payments []models.Payment
+	err := r.db.Where("reservation_id = ?", reservationID).Order("id").Find(&payments).Error
+	return payments, err
+}
+
+func (r *GormRepository) SaveInvoice(invoice *models.Invoice) error {
+	return r.db.Save(invoice).Error
+}
+
+func (r *GormRepository) Invoice(id int) (*models.Invoice, error) {
+	var invoice models.Invoice
+	if err := r.db.First(&invoice, id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &invoice, nil
+}
+
+func (r *GormRepository) Invoices() ([]models.Invoice, error) {
+	var invoices []models.Invoice
+	err := r.db.Order("id").Find(&invoices).Error
+	return invoices, err
+}
+
+func (r *GormRepository) InvoicesForReservation(reservationID int) ([]models.Invoice, error) {
+	var invoices []models.Invoice
+	err := r.db.Where("reservation_id = ?", reservationID).Order("id").Find(&invoices).Error
+	return invoices, err
+}
+
+func (r *GormRepository) SaveWaitlistEntry(entry *models.WaitlistEntry) error {
+	return r.db.Save(entry).Error
+}
+
+func (r *GormRepository) WaitlistEntry(id int) (*models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+	if err := r.db.First(&entry, id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &entry, nil
+}
+
+func (r *GormRepository) WaitlistEntries() ([]models.WaitlistEntry, error) {
+	var entries []models.WaitlistEntry
+	err := r.db.Order("id").Find(&entries).Error
+	return entries, err
+}
+
+func (r *GormRepository) SaveHold(hold *models.Hold) error {
+	return r.db.Save(hold).Error
+}
+
+func (r *GormRepository) Hold(id int) (*models.Hold, error) {
+	var hold models.Hold
+	if err := r.db.First(&hold, id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &hold, nil
+}
+
+func (r *GormRepository) Holds() ([]models.Hold, error) {
+	var holds []models.Hold
+	err := r.db.Order("id").Find(&holds).Error
+	return holds, err
+}
+
+func (r *GormRepository) SaveExtra(extra *models.Extra) error {
+	return r.db.Save(extra).Error
+}
+
+func (r *GormRepository) Extra(code string) (*models.Extra, error) {
+	var extra models.Extra
+	if err := r.db.First(&extra, "code = ?", code).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &extra, nil
+}
+
+func (r *GormRepository) Extras() ([]models.Extra, error) {
+	var extras []models.Extra
+	err := r.db.Order("code").Find(&extras).Error
+	return extras, err
+}
+
+func (r *GormRepository) SaveLoyaltyEntry(entry *models.LoyaltyEntry) error {
+	return r.db.Save(entry).Error
+}
+
+func (r *GormRepository) LoyaltyEntriesForCustomer(customerID int) ([]models.LoyaltyEntry, error) {
+	var entries []models.LoyaltyEntry
+	err := r.db.Where("customer_id = ?", customerID).Order("id").Find(&entries).Error
+	return entries, err
+}
+
+func (r *GormRepository) SavePromoCode(promo *models.PromoCode) error {
+	return r.db.Save(promo).Error
+}
+
+func (r *GormRepository) PromoCode(code string) (*models.PromoCode, error) {
+	var promo models.PromoCode
+	if err := r.db.First(&promo, "code = ?", code).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &promo, nil
+}
+
+// LockPromoCode reads the code with SELECT ... FOR UPDATE. SQLite has no
+// row locks, but it only lets one transaction write at a time.
+func (r *GormRepository) LockPromoCode(code string) (*models.PromoCode, error) {
+	var promo models.PromoCode
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&promo, "code = ?", code).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &promo, nil
+}
+
+func (r *GormRepository) PromoCodes() ([]models.PromoCode, error) {
+	var promos []models.PromoCode
+	err := r.db.Order("code").Find(&promos).Error
+	return promos, err
+}
+
+func (r *GormRepository) SavePromoRedemption(redemption *models.PromoRedemption) error {
+	return r.db.Save(redemption).Error
+}
+
+func (r *GormRepository) PromoRedemptions(code string) ([]models.PromoRedemption, error) {
+	var redemptions []models.PromoRedemption
+	err := r.db.Where("code = ?", code).Order("id").Find(&redemptions).Error
+	return redemptions, err
+}
+
+func (r *GormRepository) SaveExchangeRate(rate *models.ExchangeRate) error {
+	return r.db.Save(rate).Error
+}
+
+func (r *GormRepository) ExchangeRates() ([]models.ExchangeRate, error) {
+	var rates []models.ExchangeRate
+	err := r.db.Order("id").Find(&rates).Error
+	return rates, err
+}
+
+func (r *GormRepository) Transaction(fn func(tx Repository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&GormRepository{db: tx})
+	})
+}
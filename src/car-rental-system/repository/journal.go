@@ -0,0 +1,845 @@
+package repository
+
+import (
+	"bufio"
+	models "car-rental-system/rental_system_models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	journalFileName = "journal.jsonl"
+	snapshotPrefix  = "snapshot-"
+	snapshotSuffix  = ".json"
+
+	defaultSnapshotInterval = 1000
+)
+
+// The operations a journal change can record.
+const (
+	OpVehicleClassSaved     = "vehicle_class.saved"
+	OpBranchSaved           = "branch.saved"
+	OpCarSaved              = "car.saved"
+	OpMaintenanceSaved      = "maintenance_window.saved"
+	OpMaintenanceDeleted    = "maintenance_window.deleted"
+	OpCustomerSaved         = "customer.saved"
+	OpBlockedLicenseSaved   = "blocked_license.saved"
+	OpBlockedLicenseDeleted = "blocked_license.deleted"
+	OpReservationSaved      = "reservation.saved"
+	OpReservationDeleted    = "reservation.deleted"
+	OpPaymentSaved          = "payment.saved"
+	OpInvoiceSaved          = "invoice.saved"
+	OpWaitlistEntrySaved    = "waitlist_entry.saved"
+	OpHoldSaved             = "hold.saved"
+	OpExtraSaved            = "extra.saved"
+	OpLoyaltyEntrySaved     = "loyalty_entry.saved"
+	OpPromoCodeSaved        = "promo_code.saved"
+	OpPromoRedemptionSaved  = "promo_redemption.saved"
+	OpExchangeRateSaved     = "exchange_rate.saved"
+)
+
+// JournalEntry is one committed change to a journal: a single save or
+// delete, or everything written by one transaction.
+type JournalEntry struct {
+	Seq     int64           `json:"seq"`
+	At      time.Time       `json:"at"`
+	Changes []JournalChange `json:"changes"`
+}
+
+// JournalChange is one write. Data holds the record as it was saved, or
+// the key of the record that was deleted.
+type JournalChange struct {
+	Op   string          `json:"op"`
+	Data json.RawMessage `json:"data"`
+}
+
+// snapshot is the whole state as of a journal entry.
+type snapshot struct {
+	Seq             int64                      `json:"seq"`
+	At              time.Time                  `json:"at"`
+	VehicleClasses  []models.VehicleClass      `json:"vehicleClasses"`
+	Branches        []models.Branch            `json:"branches"`
+	Cars            []models.Car               `json:"cars"`
+	Maintenance     []models.MaintenanceWindow `json:"maintenance"`
+	Customers       []models.Customer          `json:"customers"`
+	BlockedLicenses []models.BlockedLicense    `json:"blockedLicenses"`
+	Reservations    []models.Reservation       `json:"reservations"`
+	Payments        []models.Payment           `json:"payments"`
+	Invoices        []models.Invoice           `json:"invoices"`
+	Waitlist        []models.WaitlistEntry     `json:"waitlist"`
+	Holds           []models.Hold              `json:"holds"`
+	Extras          []models.Extra             `json:"extras"`
+	Loyalty         []models.LoyaltyEntry      `json:"loyalty"`
+	PromoCodes      []models.PromoCode         `json:"promoCodes"`
+	Redemptions     []models.PromoRedemption   `json:"redemptions"`
+	ExchangeRates   []models.ExchangeRate      `json:"exchangeRates"`
+}
+
+// JournalRepository is an event-sourced store. The current state is held
+// in memory and every committed change is appended to journal.jsonl in its
+// directory before it takes effect. On open the state is rebuilt from the
+// latest snapshot plus the entries written after it; the journal itself is
+// never compacted, so it doubles as the audit history.
+//
+// Reads come straight from the embedded MemoryRepository. Every write
+// method of Repository must be overridden here, or it would skip the
+// journal.
+type JournalRepository struct {
+	*MemoryRepository
+
+	dir           string
+	snapshotEvery int
+	now           func() time.Time
+
+	// writeMu serializes writers; the fields below belong to it.
+	writeMu       sync.Mutex
+	file          *os.File
+	size          int64
+	seq           int64
+	last          time.Time
+	sinceSnapshot int
+}
+
+// JournalOption configures a JournalRepository opened by OpenJournal.
+type JournalOption func(*JournalRepository)
+
+// WithSnapshotInterval takes a snapshot after every n journal entries. Zero
+// or less turns automatic snapshots off.
+func WithSnapshotInterval(n int) JournalOption {
+	return func(r *JournalRepository) {
+		r.snapshotEvery = n
+	}
+}
+
+// WithJournalClock replaces time.Now for the timestamps of new entries.
+func WithJournalClock(now func() time.Time) JournalOption {
+	return func(r *JournalRepository) {
+		r.now = now
+	}
+}
+
+// OpenJournal opens the journal in dir, creating the directory if needed,
+// and replays it. A last entry that was only partly written, as after a
+// crash, is dropped.
+func OpenJournal(dir string, opts ...JournalOption) (*JournalRepository, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	r := &JournalRepository{dir: dir, snapshotEvery: defaultSnapshotInterval, now: time.Now}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	snap, err := latestSnapshot(dir, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	state := snap.state()
+	entries, size, err := readJournal(filepath.Join(dir, journalFileName))
+	if err != nil {
+		return nil, err
+	}
+	r.seq, r.last = snap.Seq, snap.At
+	for _, entry := range entries {
+		if entry.Seq <= snap.Seq {
+			continue
+		}
+		if err := state.apply(entry); err != nil {
+			return nil, err
+		}
+		r.seq, r.last = entry.Seq, entry.At
+		r.sinceSnapshot++
+	}
+	state.syncCounters()
+	r.MemoryRepository = &MemoryRepository{state: state}
+
+	file, err := os.OpenFile(filepath.Join(dir, journalFileName), os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	// Cut off a torn last line so new entries start on a clean one.
+	if err := file.Truncate(size); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if _, err := file.Seek(size, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+	r.file, r.size = file, size
+	return r, nil
+}
+
+// Close closes the journal file. The repository must not be written to
+// afterwards.
+func (r *JournalRepository) Close() error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+	return r.file.Close()
+}
+
+// ReadJournal returns every entry of the journal in dir, oldest first.
+func ReadJournal(dir string) ([]JournalEntry, error) {
+	entries, _, err := readJournal(filepath.Join(dir, journalFileName))
+	return entries, err
+}
+
+// RebuildAt returns the state of the journal in dir as it was at t, from
+// the latest snapshot taken by then and the entries written up to t. The
+// result is detached from the journal: changes to it are not recorded.
+func RebuildAt(dir string, t time.Time) (*MemoryRepository, error) {
+	snap, err := latestSnapshot(dir, t)
+	if err != nil {
+		return nil, err
+	}
+	state := snap.state()
+	entries, _, err := readJournal(filepath.Join(dir, journalFileName))
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.Seq <= snap.Seq {
+			continue
+		}
+		if entry.At.After(t) {
+			break
+		}
+		if err := state.apply(entry); err != nil {
+			return nil, err
+		}
+	}
+	state.syncCounters()
+	return &MemoryRepository{state: state}, nil
+}
+
+// readJournal parses the journal file, returning its entries and the size
+// of the part that holds complete ones. A missing file is an empty journal.
+func readJournal(path string) ([]JournalEntry, int64, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	defer file.Close()
+
+	var entries []JournalEntry
+	var size int64
+	reader := bufio.NewReader(file)
+	for line := 1; ; line++ {
+		raw, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// Whatever follows the last newline was never fully written.
+			return entries, size, nil
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		var entry JournalEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			if _, peekErr := reader.Peek(1); errors.Is(peekErr, io.EOF) {
+				return entries, size, nil
+			}
+			return nil, 0, fmt.Errorf("journal line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+		size += int64(len(raw))
+	}
+}
+
+// latestSnapshot loads the newest snapshot in dir, or the newest one taken
+// by before when it is set. Without one it returns an empty snapshot.
+func latestSnapshot(dir string, before time.Time) (*snapshot, error) {
+	names, err := filepath.Glob(filepath.Join(dir, snapshotPrefix+"*"+snapshotSuffix))
+	if err != nil {
+		return nil, err
+	}
+	type candidate struct {
+		path string
+		seq  int64
+	}
+	var candidates []candidate
+	for _, name := range names {
+		raw := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(name), snapshotPrefix), snapshotSuffix)
+		seq, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			continue
+		}
+		candidates = append(candidates, candidate{path: name, seq: seq})
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].seq > candidates[j].seq })
+
+	for _, c := range candidates {
+		data, err := os.ReadFile(c.path)
+		if err != nil {
+			return nil, err
+		}
+		var snap snapshot
+		if err := json.Unmarshal(data, &snap); err != nil {
+			return nil, fmt.Errorf("snapshot %s: %w", filepath.Base(c.path), err)
+		}
+		if before.IsZero() || !snap.At.After(before) {
+			return &snap, nil
+		}
+	}
+	return &snapshot{}, nil
+}
+
+func (s *snapshot) state() *memoryState {
+	state := NewMemoryRepository().state
+	for _, class := range s.VehicleClasses {
+		state.classes.set(class.Code, class)
+	}
+	for _, branch := range s.Branches {
+		state.branches.set(branch.ID, branch)
+	}
+	for _, car := range s.Cars {
+		state.cars.set(car.ID, car)
+	}
+	for _, window := range s.Maintenance {
+		state.maintenance.set(window.ID, window)
+	}
+	for _, customer := range s.Customers {
+		state.customers.set(customer.ID, customer)
+	}
+	for _, entry := range s.BlockedLicenses {
+		state.blocklist.set(entry.License, entry)
+	}
+	for _, res := range s.Reservations {
+		state.reservations.set(res.ID, res)
+	}
+	for _, payment := range s.Payments {
+		state.payments.set(payment.ID, payment)
+	}
+	for _, invoice := range s.Invoices {
+		state.invoices.set(invoice.ID, invoice)
+	}
+	for _, entry := range s.Waitlist {
+		state.waitlist.set(entry.ID, entry)
+	}
+	for _, hold := range s.Holds {
+		state.holds.set(hold.ID, hold)
+	}
+	for _, extra := range s.Extras {
+		state.extras.set(extra.Code, extra)
+	}
+	for _, entry := range s.Loyalty {
+		state.loyalty.set(entry.ID, entry)
+	}
+	for _, promo := range s.PromoCodes {
+		state.promos.set(promo.Code, promo)
+	}
+	for _, redemption := range s.Redemptions {
+		state.redemptions.set(redemption.ID, redemption)
+	}
+	for _, rate := range s.ExchangeRates {
+		state.rates.set(rate.ID, rate)
+	}
+	state.syncCounters()
+	return state
+}
+
+// newSnapshot copies state into a snapshot taken at entry seq.
+func newSnapshot(state *memoryState, seq int64, at time.Time) *snapshot {
+	snap := &snapshot{Seq: seq, At: at}
+	for _, class := range state.classes.rows {
+		snap.VehicleClasses = append(snap.VehicleClasses, class)
+	}
+	for _, branch := range state.branches.rows {
+		snap.Branches = append(snap.Branches, branch)
+	}
+	for _, car := range state.cars.rows {
+		snap.Cars = append(snap.Cars, car)
+	}
+	for _, window := range state.maintenance.rows {
+		snap.Maintenance = append(snap.Maintenance, window)
+	}
+	for _, customer := range state.customers.rows {
+		snap.Customers = append(snap.Customers, customer)
+	}
+	for _, entry := range state.blocklist.rows {
+		snap.BlockedLicenses = append(snap.BlockedLicenses, entry)
+	}
+	for _, res := range state.reservations.rows {
+		snap.Reservations = append(snap.Reservations, res)
+	}
+	for _, payment := range state.payments.rows {
+		snap.Payments = append(snap.Payments, payment)
+	}
+	for _, invoice := range state.invoices.rows {
+		snap.Invoices = append(snap.Invoices, invoice)
+	}
+	for _, entry := range state.waitlist.rows {
+		snap.Waitlist = append(snap.Waitlist, entry)
+	}
+	for _, hold := range state.holds.rows {
+		snap.Holds = append(snap.Holds, hold)
+	}
+	for _, extra := range state.extras.rows {
+		snap.Extras = append(snap.Extras, extra)
+	}
+	for _, entry := range state.loyalty.rows {
+		snap.Loyalty = append(snap.Loyalty, entry)
+	}
+	for _, promo := range state.promos.rows {
+		snap.PromoCodes = append(snap.PromoCodes, promo)
+	}
+	for _, redemption := range state.redemptions.rows {
+		snap.Redemptions = append(snap.Redemptions, redemption)
+	}
+	for _, rate := range state.rates.rows {
+		snap.ExchangeRates = append(snap.ExchangeRates, rate)
+	}
+	return snap
+}
+
+// apply replays one journal entry.
+func (s *memoryState) apply(entry JournalEntry) error {
+	for _, change := range entry.Changes {
+		var err error
+		switch change.Op {
+		case OpVehicleClassSaved:
+			var class models.VehicleClass
+			if err = json.Unmarshal(change.Data, &class); err == nil {
+				s.classes.set(class.Code, class)
+			}
+		case OpBranchSaved:
+			var branch models.Branch
+			if err = json.Unmarshal(change.Data, &branch); err == nil {
+				s.branches.set(branch.ID, branch)
+			}
+		case OpCarSaved:
+			var car models.Car
+			if err = json.Unmarshal(change.Data, &car); err == nil {
+				s.cars.set(car.ID, car)
+			}
+		case OpMaintenanceSaved:
+			var window models.MaintenanceWindow
+			if err = json.Unmarshal(change.Data, &window); err == nil {
+				s.maintenance.set(window.ID, window)
+			}
+		case OpMaintenanceDeleted:
+			var id int
+			if err = json.Unmarshal(change.Data, &id); err == nil {
+				s.maintenance.delete(id)
+			}
+		case OpCustomerSaved:
+			var customer models.Customer
+			if err = json.Unmarshal(change.Data, &customer); err == nil {
+				s.customers.set(customer.ID, customer)
+			}
+		case OpBlockedLicenseSaved:
+			var entry models.BlockedLicense
+			if err = json.Unmarshal(change.Data, &entry); err == nil {
+				s.blocklist.set(entry.License, entry)
+			}
+		case OpBlockedLicenseDeleted:
+			var license string
+			if err = json.Unmarshal(change.Data, &license); err == nil {
+				s.blocklist.delete(license)
+			}
+		case OpReservationSaved:
+			var res models.Reservation
+			if err = json.Unmarshal(change.Data, &res); err == nil {
+				s.reservations.set(res.ID, res)
+			}
+		case OpReservationDeleted:
+			var id int
+			if err = json.Unmarshal(change.Data, &id); err == nil {
+				s.reservations.delete(id)
+			}
+		case OpPaymentSaved:
+			var payment models.Payment
+			if err = json.Unmarshal(change.Data, &payment); err == nil {
+				s.payments.set(payment.ID, payment)
+			}
+		case OpInvoiceSaved:
+			var invoice models.Invoice
+			if err = json.Unmarshal(change.Data, &invoice); err == nil {
+				s.invoices.set(invoice.ID, invoice)
+			}
+		case OpWaitlistEntrySaved:
+			var entry models.WaitlistEntry
+			if err = json.Unmarshal(change.Data, &entry); err == nil {
+				s.waitlist.set(entry.ID, entry)
+			}
+		case OpHoldSaved:
+			var hold models.Hold
+			if err = json.Unmarshal(change.Data, &hold); err == nil {
+				s.holds.set(hold.ID, hold)
+			}
+		case OpExtraSaved:
+			var extra models.Extra
+			if err = json.Unmarshal(change.Data, &extra); err == nil {
+				s.extras.set(extra.Code, extra)
+			}
+		case OpLoyaltyEntrySaved:
+			var entry models.LoyaltyEntry
+			if err = json.Unmarshal(change.Data, &entry); err == nil {
+				s.loyalty.set(entry.ID, entry)
+			}
+		case OpPromoCodeSaved:
+			var promo models.PromoCode
+			if err = json.Unmarshal(change.Data, &promo); err == nil {
+				s.promos.set(promo.Code, promo)
+			}
+		case OpPromoRedemptionSaved:
+			var redemption models.PromoRedemption
+			if err = json.Unmarshal(change.Data, &redemption); err == nil {
+				s.redemptions.set(redemption.ID, redemption)
+			}
+		case OpExchangeRateSaved:
+			var rate models.ExchangeRate
+			if err = json.Unmarshal(change.Data, &rate); err == nil {
+				s.rates.set(rate.ID, rate)
+			}
+		default:
+			err = fmt.Errorf("unknown operation %q", change.Op)
+		}
+		if err != nil {
+			return fmt.Errorf("journal entry %d: %w", entry.Seq, err)
+		}
+	}
+	return nil
+}
+
+// Snapshot writes the current state to disk so the next open only has to
+// replay the entries written after it.
+func (r *JournalRepository) Snapshot() error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+	return r.snapshot()
+}
+
+func (r *JournalRepository) snapshot() error {
+	r.MemoryRepository.mu.Lock()
+	// Transactions swap in a new state rather than changing this one, so
+	// it can be read after the lock is released.
+	state := r.MemoryRepository.state
+	r.MemoryRepository.mu.Unlock()
+
+	data, err := json.Marshal(newSnapshot(state, r.seq, r.last))
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(r.dir, fmt.Sprintf("%s%020d%s", snapshotPrefix, r.seq, snapshotSuffix))
+	tmp, err := os.CreateTemp(r.dir, snapshotPrefix+"*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	r.sinceSnapshot = 0
+	return nil
+}
+
+// append writes an entry for changes and flushes it to disk. On failure
+// the file is cut back so no partial entry is left behind.
+func (r *JournalRepository) append(changes []JournalChange) error {
+	at := r.now().UTC()
+	// Entries stay in time order even if the clock steps back, which
+	// RebuildAt relies on.
+	if at.Before(r.last) {
+		at = r.last
+	}
+	entry := JournalEntry{Seq: r.seq + 1, At: at, Changes: changes}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	_, err = r.file.Write(line)
+	if err == nil {
+		err = r.file.Sync()
+	}
+	if err != nil {
+		r.file.Truncate(r.size)
+		r.file.Seek(r.size, io.SeekStart)
+		return err
+	}
+	r.size += int64(len(line))
+	r.seq, r.last = entry.Seq, at
+	r.sinceSnapshot++
+	return nil
+}
+
+// Transaction runs fn against a copy of the state and, when it succeeds,
+// journals everything fn wrote as one entry before swapping the copy in.
+func (r *JournalRepository) Transaction(fn func(tx Repository) error) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	err := r.MemoryRepository.Transaction(func(memTx Repository) error {
+		tx := &journalTx{Repository: memTx}
+		if err := fn(tx); err != nil {
+			return err
+		}
+		if len(tx.changes) == 0 {
+			return nil
+		}
+		return r.append(tx.changes)
+	})
+	if err != nil {
+		return err
+	}
+	if r.snapshotEvery > 0 && r.sinceSnapshot >= r.snapshotEvery {
+		// The change is already safe in the journal; a failed snapshot is
+		// retried after the next write.
+		r.snapshot()
+	}
+	return nil
+}
+
+func (r *JournalRepository) SaveCar(car *models.Car) error {
+	return r.Transaction(func(tx Repository) error { return tx.SaveCar(car) })
+}
+
+func (r *JournalRepository) SaveVehicleClass(class *models.VehicleClass) error {
+	return r.Transaction(func(tx Repository) error { return tx.SaveVehicleClass(class) })
+}
+
+func (r *JournalRepository) SaveBranch(branch *models.Branch) error {
+	return r.Transaction(func(tx Repository) error { return tx.SaveBranch(branch) })
+}
+
+func (r *JournalRepository) SaveMaintenanceWindow(window *models.MaintenanceWindow) error {
+	return r.Transaction(func(tx Repository) error { return tx.SaveMaintenanceWindow(window) })
+}
+
+func (r *JournalRepository) DeleteMaintenanceWindow(id int) error {
+	return r.Transaction(func(tx Repository) error { return tx.DeleteMaintenanceWindow(id) })
+}
+
+func (r *JournalRepository) SaveCustomer(customer *models.Customer) error {
+	return r.Transaction(func(tx Repository) error { return tx.SaveCustomer(customer) })
+}
+
+func (r *JournalRepository) SaveBlockedLicense(entry *models.BlockedLicense) error {
+	return r.Transaction(func(tx Repository) error { return tx.SaveBlockedLicense(entry) })
+}
+
+func (r *JournalRepository) DeleteBlockedLicense(license string) error {
+	return r.Transaction(func(tx Repository) error { return tx.DeleteBlockedLicense(license) })
+}
+
+func (r *JournalRepository) SaveReservation(res *models.Reservation) error {
+	return r.Transaction(func(tx Repository) error { return tx.SaveReservation(res) })
+}
+
+func (r *JournalRepository) DeleteReservation(id int) error {
+	return r.Transaction(func(tx Repository) error { return tx.DeleteReservation(id) })
+}
+
+func (r *JournalRepository) SavePayment(payment *models.Payment) error {
+	return r.Transaction(func(tx Repository) error { return tx.SavePayment(payment) })
+}
+
+func (r *JournalRepository) SaveInvoice(invoice *models.Invoice) error {
+	return r.Transaction(func(tx Repository) error { return tx.SaveInvoice(invoice) })
+}
+
+func (r *JournalRepository) SaveWaitlistEntry(entry *models.WaitlistEntry) error {
+	return r.Transaction(func(tx Repository) error { return tx.SaveWaitlistEntry(entry) })
+}
+
+func (r *JournalRepository) SaveHold(hold *models.Hold) error {
+	return r.Transaction(func(tx Repository) error { return tx.SaveHold(hold) })
+}
+
+func (r *JournalRepository) SaveExtra(extra *models.Extra) error {
+	return r.Transaction(func(tx Repository) error { return tx.SaveExtra(extra) })
+}
+
+func (r *JournalRepository) SaveLoyaltyEntry(entry *models.LoyaltyEntry) error {
+	return r.Transaction(func(tx Repository) error { return tx.SaveLoyaltyEntry(entry) })
+}
+
+func (r *JournalRepository) SavePromoCode(promo *models.PromoCode) error {
+	return r.Transaction(func(tx Repository) error { return tx.SavePromoCode(promo) })
+}
+
+func (r *JournalRepository) SavePromoRedemption(redemption *models.PromoRedemption) error {
+	return r.Transaction(func(tx Repository) error { return tx.SavePromoRedemption(redemption) })
+}
+
+func (r *JournalRepository) SaveExchangeRate(rate *models.ExchangeRate) error {
+	return r.Transaction(func(tx Repository) error { return tx.SaveExchangeRate(rate) })
+}
+
+// journalTx records the writes made through it on top of a memory
+// transaction.
+type journalTx struct {
+	Repository
+	changes []JournalChange
+}
+
+func (t *journalTx) record(op string, data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	t.changes = append(t.changes, JournalChange{Op: op, Data: raw})
+	return nil
+}
+
+func (t *journalTx) SaveCar(car *models.Car) error {
+	if err := t.Repository.SaveCar(car); err != nil {
+		return err
+	}
+	return t.record(OpCarSaved, car)
+}
+
+func (t *journalTx) SaveVehicleClass(class *models.VehicleClass) error {
+	if err := t.Repository.SaveVehicleClass(class); err != nil {
+		return err
+	}
+	return t.record(OpVehicleClassSaved, class)
+}
+
+func (t *journalTx) SaveBranch(branch *models.Branch) error {
+	if err := t.Repository.SaveBranch(branch); err != nil {
+		return err
+	}
+	return t.record(OpBranchSaved, branch)
+}
+
+func (t *journalTx) SaveMaintenanceWindow(window *models.MaintenanceWindow) error {
+	if err := t.Repository.SaveMaintenanceWindow(window); err != nil {
+		return err
+	}
+	return t.record(OpMaintenanceSaved, window)
+}
+
+func (t *journalTx) DeleteMaintenanceWindow(id int) error {
+	if err := t.Repository.DeleteMaintenanceWindow(id); err != nil {
+		return err
+	}
+	return t.record(OpMaintenanceDeleted, id)
+}
+
+func (t *journalTx) SaveCustomer(customer *models.Customer) error {
+	if err := t.Repository.SaveCustomer(customer); err != nil {
+		return err
+	}
+	return t.record(OpCustomerSaved, customer)
+}
+
+func (t *journalTx) SaveBlockedLicense(entry *models.BlockedLicense) error {
+	if err := t.Repository.SaveBlockedLicense(entry); err != nil {
+		return err
+	}
+	return t.record(OpBlockedLicenseSaved, entry)
+}
+
+func (t *journalTx) DeleteBlockedLicense(license string) error {
+	if err := t.Repository.DeleteBlockedLicense(license); err != nil {
+		return err
+	}
+	return t.record(OpBlockedLicenseDeleted, license)
+}
+
+func (t *journalTx) SaveReservation(res *models.Reservation) error {
+	if err := t.Repository.SaveReservation(res); err != nil {
+		return err
+	}
+	return t.record(OpReservationSaved, res)
+}
+
+func (t *journalTx) DeleteReservation(id int) error {
+	if err := t.Repository.DeleteReservation(id); err != nil {
+		return err
+	}
+	return t.record(OpReservationDeleted, id)
+}
+
+func (t *journalTx) SavePayment(payment *models.Payment) error {
+	if err := t.Repository.SavePayment(payment); err != nil {
+		return err
+	}
+	return t.record(OpPaymentSaved, payment)
+}
+
+func (t *journalTx) SaveInvoice(invoice *models.Invoice) error {
+	if err := t.Repository.SaveInvoice(invoice); err != nil {
+		return err
+	}
+	return t.record(OpInvoiceSaved, invoice)
+}
+
+func (t *journalTx) SaveWaitlistEntry(entry *models.WaitlistEntry) error {
+	if err := t.Repository.SaveWaitlistEntry(entry); err != nil {
+		return err
+	}
+	return t.record(OpWaitlistEntrySaved, entry)
+}
+
+func (t *journalTx) SaveHold(hold *models.Hold) error {
+	if err := t.Repository.SaveHold(hold); err != nil {
+		return err
+	}
+	return t.record(OpHoldSaved, hold)
+}
+
+func (t *journalTx) SaveExtra(extra *models.Extra) error {
+	if err := t.Repository.SaveExtra(extra); err != nil {
+		return err
+	}
+	return t.record(OpExtraSaved, extra)
+}
+
+func (t *journalTx) SaveLoyaltyEntry(entry *models.LoyaltyEntry) error {
+	if err := t.Repository.SaveLoyaltyEntry(entry); err != nil {
+		return err
+	}
+	return t.record(OpLoyaltyEntrySaved, entry)
+}
+
+func (t *journalTx) SavePromoCode(promo *models.PromoCode) error {
+	if err := t.Repository.SavePromoCode(promo); err != nil {
+		return err
+	}
+	return t.record(OpPromoCodeSaved, promo)
+}
+
+func (t *journalTx) SavePromoRedemption(redemption *models.PromoRedemption) error {
+	if err := t.Repository.SavePromoRedemption(redemption); err != nil {
+		return err
+	}
+	return t.record(OpPromoRedemptionSaved, redemption)
+}
+
+func (t *journalTx) SaveExchangeRate(rate *models.ExchangeRate) error {
+	if err := t.Repository.SaveExchangeRate(rate); err != nil {
+		return err
+	}
+	return t.record(OpExchangeRateSaved, rate)
+}
+
+// Transaction runs fn in the transaction already open.
+func (t *journalTx) Transaction(fn func(tx Repository) error) error {
+	return fn(t)
+}
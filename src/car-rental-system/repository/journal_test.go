@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var errRollback = errors.New("rollback")
+
+// journalSteps writes one of each kind of change, plus a transaction that
+// fails and must leave no trace. Every other step is one journal entry.
+var journalSteps = []func(r Repository) error{
+	func(r Repository) error {
+		return r.SaveVehicleClass(&models.VehicleClass{Code: "economy", Name: "Economy", Rank: 1})
+	},
+	func(r Repository) error {
+		return r.SaveCar(&models.Car{ID: 1, Make: "Toyota", Model: "Corolla", Class: "economy", RentalPricePerDay: money.New(50_00, money.USD)})
+	},
+	func(r Repository) error {
+		return r.SaveCustomer(&models.Customer{Name: "Ann Lee", DriversLicense: "D7654321"})
+	},
+	func(r Repository) error {
+		return r.Transaction(func(tx Repository) error {
+			res := &models.Reservation{CarID: 1, CustomerID: 1, TotalPrice: money.New(100_00, money.USD)}
+			if err := tx.SaveReservation(res); err != nil {
+				return err
+			}
+			if err := tx.SavePayment(&models.Payment{ReservationID: res.ID, Amount: money.New(100_00, money.USD)}); err != nil {
+				return err
+			}
+			return tx.SaveInvoice(&models.Invoice{Number: "INV-000001", ReservationID: res.ID, Total: money.New(100_00, money.USD)})
+		})
+	},
+	func(r Repository) error {
+		return r.SaveReservation(&models.Reservation{CarID: 1, CustomerID: 1})
+	},
+	func(r Repository) error { return r.DeleteReservation(2) },
+	func(r Repository) error {
+		err := r.Transaction(func(tx Repository) error {
+			if err := tx.SaveCar(&models.Car{ID: 2, Make: "Honda", Model: "Civic"}); err != nil {
+				return err
+			}
+			return errRollback
+		})
+		if errors.Is(err, errRollback) {
+			return nil
+		}
+		return err
+	},
+	func(r Repository) error { return r.SaveBlockedLicense(&models.BlockedLicense{License: "X1"}) },
+	func(r Repository) error { return r.DeleteBlockedLicense("X1") },
+	func(r Repository) error {
+		return r.SaveCar(&models.Car{ID: 1, Make: "Toyota", Model: "Corolla", Class: "economy", RentalPricePerDay: money.New(60_00, money.USD)})
+	},
+}
+
+// dump renders what r holds so two repositories can be compared.
+func dump(t *testing.T, r Repository) string {
+	t.Helper()
+	var state []any
+	add := func(v any, err error) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		state = append(state, v)
+	}
+	add(r.VehicleClasses())
+	add(r.Cars())
+	add(r.ReservationsForCustomer(1))
+	add(r.Reservations())
+	add(r.PaymentsForReservation(1))
+	add(r.Invoices())
+	add(r.BlockedLicenses())
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestJournalReplay(t *testing.T) {
+	tests := []struct {
+		name          string
+		interval      int
+		snapshotAfter int
+		wantSnapshots int
+	}{
+		{name: "journal only", interval: 0},
+		{name: "snapshot after every entry", interval: 1, wantSnapshots: 9},
+		{name: "snapshot every fourth entry", interval: 4, wantSnapshots: 2},
+		{name: "manual snapshot", interval: 0, snapshotAfter: 5, wantSnapshots: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			r, err := OpenJournal(dir, WithSnapshotInterval(tt.interval))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, step := range journalSteps {
+				if err := step(r); err != nil {
+					t.Fatalf("step %d: %v", i, err)
+				}
+				if i+1 == tt.snapshotAfter {
+					if err := r.Snapshot(); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+			want := dump(t, r)
+			if err := r.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			entries, err := ReadJournal(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != 9 {
+				t.Errorf("journal has %d entries, want 9", len(entries))
+			}
+			snapshots, _ := filepath.Glob(filepath.Join(dir, snapshotPrefix+"*"+snapshotSuffix))
+			if len(snapshots) != tt.wantSnapshots {
+				t.Errorf("%d snapshots taken, want %d", len(snapshots), tt.wantSnapshots)
+			}
+
+			reopened, err := OpenJournal(dir, WithSnapshotInterval(tt.interval))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer reopened.Close()
+			if got := dump(t, reopened); got != want {
+				t.Errorf("replayed state:\n%s\nwant:\n%s", got, want)
+			}
+			customer := &models.Customer{Name: "Bo Chen", DriversLicense: "B1234567"}
+			if err := reopened.SaveCustomer(customer); err != nil {
+				t.Fatal(err)
+			}
+			if customer.ID != 2 {
+				t.Errorf("new customer after replay got id %d, want 2", customer.ID)
+			}
+			if entries, _ := ReadJournal(dir); len(entries) != 10 || entries[9].Seq != 10 {
+				t.Errorf("journal after replay has %d entries, want the tenth with seq 10", len(entries))
+			}
+		})
+	}
+}
+
+func TestJournalDropsTornEntry(t *testing.T) {
+	dir := t.TempDir()
+	r, err := OpenJournal(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, step := range journalSteps[:3] {
+		if err := step(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := dump(t, r)
+	r.Close()
+
+	file, err := os.OpenFile(filepath.Join(dir, journalFileName), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(`{"seq":4,"at":"2025-03-01T09:00:00Z","changes":[{"op":"car.sa`)
+	file.Close()
+
+	r, err = OpenJournal(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := dump(t, r); got != want {
+		t.Errorf("state with a torn entry:\n%s\nwant:\n%s", got, want)
+	}
+	if err := journalSteps[3](r); err != nil {
+		t.Fatal(err)
+	}
+	r.Close()
+	entries, err := ReadJournal(dir)
+	if err != nil {
+		t.Fatalf("journal after writing past a torn entry: %v", err)
+	}
+	if len(entries) != 4 || entries[3].Seq != 4 {
+		t.Errorf("journal has %d entries, want 4 ending with seq 4", len(entries))
+	}
+}
+
+func TestRebuildAt(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+	now := start
+	r, err := OpenJournal(dir, WithJournalClock(func() time.Time { return now }), WithSnapshotInterval(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	price := func(amount int64) error {
+		now = now.Add(time.Hour)
+		return r.SaveCar(&models.Car{ID: 1, Make: "Toyota", Model: "Corolla", RentalPricePerDay: money.New(amount, money.USD)})
+	}
+	for _, amount := range []int64{50_00, 55_00} {
+		if err := price(amount); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+	if err := price(60_00); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want money.Money
+	}{
+		{"before the car was added", start, money.Money{}},
+		{"first price", start.Add(time.Hour), money.New(50_00, money.USD)},
+		{"between entries", start.Add(90 * time.Minute), money.New(50_00, money.USD)},
+		{"at the snapshot", start.Add(2 * time.Hour), money.New(55_00, money.USD)},
+		{"after the snapshot", start.Add(3 * time.Hour), money.New(60_00, money.USD)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rebuilt, err := RebuildAt(dir, tt.at)
+			if err != nil {
+				t.Fatal(err)
+			}
+			car, err := rebuilt.Car(1)
+			if errors.Is(err, ErrNotFound) && tt.want == (money.Money{}) {
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if car.RentalPricePerDay != tt.want {
+				t.Errorf("price = %v, want %v", car.RentalPricePerDay, tt.want)
+			}
+		})
+	}
+}
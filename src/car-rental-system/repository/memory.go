@@ -0,0 +1,718 @@
+package repository
+
+import (
+	models "car-rental-system/rental_system_models"
+	"maps"
+	"sort"
+	"sync"
+)
+
+// MemoryRepository keeps everything in maps and loses it on exit.
+type MemoryRepository struct {
+	mu    sync.Mutex
+	state *memoryState
+}
+
+type memoryState struct {
+	classes       table[string, models.VehicleClass]
+	branches      table[int, models.Branch]
+	cars          table[int, models.Car]
+	maintenance   table[int, models.MaintenanceWindow]
+	customers     table[int, models.Customer]
+	blocklist     table[string, models.BlockedLicense]
+	reservations  table[int, models.Reservation]
+	payments      table[int, models.Payment]
+	invoices      table[int, models.Invoice]
+	waitlist      table[int, models.WaitlistEntry]
+	holds         table[int, models.Hold]
+	extras        table[string, models.Extra]
+	loyalty       table[int, models.LoyaltyEntry]
+	promos        table[string, models.PromoCode]
+	redemptions   table[int, models.PromoRedemption]
+	rates         table[int, models.ExchangeRate]
+	branchID      int
+	maintenanceID int
+	customerID    int
+	reservationID int
+	paymentID     int
+	invoiceID     int
+	waitlistID    int
+	holdID        int
+	loyaltyID     int
+	redemptionID  int
+	rateID        int
+}
+
+// table is one kind of record in a memoryState. Its rows may be shared
+// with other states, such as a transaction's; the first write after that
+// copies them, so writes never show through to the other states.
+type table[K comparable, V any] struct {
+	rows   map[K]V
+	shared bool
+}
+
+func newTable[K comparable, V any]() table[K, V] {
+	return table[K, V]{rows: make(map[K]V)}
+}
+
+func (t *table[K, V]) set(key K, value V) {
+	t.own()
+	t.rows[key] = value
+}
+
+func (t *table[K, V]) delete(key K) {
+	t.own()
+	delete(t.rows, key)
+}
+
+// own copies the rows if they are shared.
+func (t *table[K, V]) own() {
+	if t.shared {
+		t.rows = maps.Clone(t.rows)
+		t.shared = false
+	}
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{state: &memoryState{
+		classes:      newTable[string, models.VehicleClass](),
+		branches:     newTable[int, models.Branch](),
+		cars:         newTable[int, models.Car](),
+		maintenance:  newTable[int, models.MaintenanceWindow](),
+		customers:    newTable[int, models.Customer](),
+		blocklist:    newTable[string, models.BlockedLicense](),
+		reservations: newTable[int, models.Reservation](),
+		payments:     newTable[int, models.Payment](),
+		invoices:     newTable[int, models.Invoice](),
+		waitlist:     newTable[int, models.WaitlistEntry](),
+		holds:        newTable[int, models.Hold](),
+		extras:       newTable[string, models.Extra](),
+		loyalty:      newTable[int, models.LoyaltyEntry](),
+		promos:       newTable[string, models.PromoCode](),
+		redemptions:  newTable[int, models.PromoRedemption](),
+		rates:        newTable[int, models.ExchangeRate](),
+	}}
+}
+
+// share returns a copy of s that shares its rows, marking them shared in
+// both so that each copies a table the first time it writes to it. Only
+// the tables a transaction writes to are ever copied.
+func (s *memoryState) share() *memoryState {
+	for _, shared := range []*bool{
+		&s.classes.shared, &s.branches.shared, &s.cars.shared, &s.maintenance.shared,
+		&s.customers.shared, &s.blocklist.shared, &s.reservations.shared, &s.payments.shared,
+		&s.invoices.shared, &s.waitlist.shared, &s.holds.shared, &s.extras.shared,
+		&s.loyalty.shared, &s.promos.shared, &s.redemptions.shared, &s.rates.shared,
+	} {
+		*shared = true
+	}
+	c := *s
+	return &c
+}
+
+// syncCounters moves the ID counters past every stored ID, for state that
+// was loaded rather than saved through the repository.
+func (s *memoryState) syncCounters() {
+	for id := range s.branches.rows {
+		s.branchID = max(s.branchID, id)
+	}
+	for id := range s.maintenance.rows {
+		s.maintenanceID = max(s.maintenanceID, id)
+	}
+	for id := range s.customers.rows {
+		s.customerID = max(s.customerID, id)
+	}
+	for id := range s.reservations.rows {
+		s.reservationID = max(s.reservationID, id)
+	}
+	for id := range s.payments.rows {
+		s.paymentID = max(s.paymentID, id)
+	}
+	for id := range s.invoices.rows {
+		s.invoiceID = max(s.invoiceID, id)
+	}
+	for id := range s.waitlist.rows {
+		s.waitlistID = max(s.waitlistID, id)
+	}
+	for id := range s.holds.rows {
+		s.holdID = max(s.holdID, id)
+	}
+	for id := range s.loyalty.rows {
+		s.loyaltyID = max(s.loyaltyID, id)
+	}
+	for id := range s.redemptions.rows {
+		s.redemptionID = max(s.redemptionID, id)
+	}
+	for id := range s.rates.rows {
+		s.rateID = max(s.rateID, id)
+	}
+}
+
+func (r *MemoryRepository) SaveCar(car *models.Car) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.state.cars.set(car.ID, *car)
+	return nil
+}
+
+func (r *MemoryRepository) Car(id int) (*models.Car, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	car, exists := r.state.cars.rows[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &car, nil
+}
+
+func (r *MemoryRepository) Cars() ([]models.Car, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cars := make([]models.Car, 0, len(r.state.cars.rows))
+	for _, car := range r.state.cars.rows {
+		cars = append(cars, car)
+	}
+	sort.Slice(cars, func(i, j int) bool { return cars[i].ID < cars[j].ID })
+	return cars, nil
+}
+
+func (r *MemoryRepository) SaveVehicleClass(class *models.VehicleClass) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.state.classes.set(class.Code, *class)
+	return nil
+}
+
+func (r *MemoryRepository) VehicleClass(code string) (*models.VehicleClass, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	class, exists := r.state.classes.rows[code]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &class, nil
+}
+
+func (r *MemoryRepository) VehicleClasses() ([]models.VehicleClass, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	classes := make([]models.VehicleClass, 0, len(r.state.classes.rows))
+	for _, class := range r.state.classes.rows {
+		classes = append(classes, class)
+	}
+	sort.Slice(classes, func(i, j int) bool {
+		if classes[i].Rank != classes[j].Rank {
+			return classes[i].Rank < classes[j].Rank
+		}
+		return classes[i].Code < classes[j].Code
+	})
+	return classes, nil
+}
+
+func (r *MemoryRepository) SaveBranch(branch *models.Branch) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if branch.ID == 0 {
+		r.state.branchID++
+		branch.ID = r.state.branchID
+	}
+	r.state.branches.set(branch.ID, *branch)
+	return nil
+}
+
+func (r *MemoryRepository) Branch(id int) (*models.Branch, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	branch, exists := r.state.branches.rows[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &branch, nil
+}
+
+func (r *MemoryRepository) Branches() ([]models.Branch, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	branches := make([]models.Branch, 0, len(r.state.branches.rows))
+	for _, branch := range r.state.branches.rows {
+		branches = append(branches, branch)
+	}
+	sort.Slice(branches, func(i, j int) bool { return branches[i].ID < branches[j].ID })
+	return branches, nil
+}
+
+func (r *MemoryRepository) SaveMaintenanceWindow(window *models.MaintenanceWindow) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if window.ID == 0 {
+		r.state.maintenanceID++
+		window.ID = r.state.maintenanceID
+	}
+	r.state.maintenance.set(window.ID, *window)
+	return nil
+}
+
+func (r *MemoryRepository) MaintenanceWindow(id int) (*models.MaintenanceWindow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	window, exists := r.state.maintenance.rows[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &window, nil
+}
+
+func (r *MemoryRepository) MaintenanceWindows() ([]models.MaintenanceWindow, error) {
+	return r.maintenanceWindows(func(models.MaintenanceWindow) bool { return true })
+}
+
+func (r *MemoryRepository) MaintenanceWindowsForCar(carID int) ([]models.MaintenanceWindow, error) {
+	return r.maintenanceWindows(func(w models.MaintenanceWindow) bool { return w.CarID == carID })
+}
+
+// maintenanceWindows returns the windows matching keep, earliest first.
+func (r *MemoryRepository) maintenanceWindows(keep func(models.MaintenanceWindow) bool) ([]models.MaintenanceWindow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var windows []models.MaintenanceWindow
+	for _, window := range r.state.maintenance.rows {
+		if keep(window) {
+			windows = append(windows, window)
+		}
+	}
+	sort.Slice(windows, func(i, j int) bool {
+		if !windows[i].Start.Equal(windows[j].Start) {
+			return windows[i].Start.Before(windows[j].Start)
+		}
+		return windows[i].ID < windows[j].ID
+	})
+	return windows, nil
+}
+
+func (r *MemoryRepository) DeleteMaintenanceWindow(id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.state.maintenance.rows[id]; !exists {
+		return ErrNotFound
+	}
+	r.state.maintenance.delete(id)
+	return nil
+}
+
+func (r *MemoryRepository) SaveCustomer(customer *models.Customer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if customer.ID == 0 {
+		r.state.customerID++
+		customer.ID = r.state.customerID
+	}
+	r.state.customers.set(customer.ID, *customer)
+	return nil
+}
+
+func (r *MemoryRepository) Customer(id int) (*models.Customer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	customer, exists := r.state.customers.rows[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &customer, nil
+}
+
+func (r *MemoryRepository) CustomerByLicense(license string) (*models.Customer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, customer := range r.state.customers.rows {
+		if customer.DriversLicense == license {
+			return &customer, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *MemoryRepository) ReservationsForCustomer(customerID int) ([]models.Reservation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var reservations []models.Reservation
+	for _, res := range r.state.reservations.rows {
+		if res.CustomerID == customerID {
+			reservations = append(reservations, res)
+		}
+	}
+	sort.Slice(reservations, func(i, j int) bool { return reservations[i].ID < reservations[j].ID })
+	return reservations, nil
+}
+
+func (r *MemoryRepository) SaveBlockedLicense(entry *models.BlockedLicense) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.state.blocklist.set(entry.License, *entry)
+	return nil
+}
+
+func (r *MemoryRepository) BlockedLicense(license string) (*models.BlockedLicense, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry, exists := r.state.blocklist.rows[license]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &entry, nil
+}
+
+func (r *MemoryRepository) BlockedLicenses() ([]models.BlockedLicense, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entries := make([]models.BlockedLicense, 0, len(r.state.blocklist.rows))
+	for _, entry := range r.state.blocklist.rows {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].License < entries[j].License })
+	return entries, nil
+}
+
+func (r *MemoryRepository) DeleteBlockedLicense(license string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.state.blocklist.rows[license]; !exists {
+		return ErrNotFound
+	}
+	r.state.blocklist.delete(license)
+	return nil
+}
+
+func (r *MemoryRepository) SaveReservation(res *models.Reservation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if res.ID == 0 {
+		r.state.reservationID++
+		res.ID = r.state.reservationID
+	}
+	r.state.reservations.set(res.ID, *res)
+	return nil
+}
+
+func (r *MemoryRepository) Reservation(id int) (*models.Reservation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res, exists := r.state.reservations.rows[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &res, nil
+}
+
+func (r *MemoryRepository) Reservations() ([]models.Reservation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	reservations := make([]models.Reservation, 0, len(r.state.reservations.rows))
+	for _, res := range r.state.reservations.rows {
+		reservations = append(reservations, res)
+	}
+	sort.Slice(reservations, func(i, j int) bool { return reservations[i].ID < reservations[j].ID })
+	return reservations, nil
+}
+
+func (r *MemoryRepository) DeleteReservation(id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.state.reservations.rows[id]; !exists {
+		return ErrNotFound
+	}
+	r.state.reservations.delete(id)
+	return nil
+}
+
+func (r *MemoryRepository) SavePayment(payment *models.Payment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if payment.ID == 0 {
+		r.state.paymentID++
+		payment.ID = r.state.paymentID
+	}
+	r.state.payments.set(payment.ID, *payment)
+	return nil
+}
+
+func (r *MemoryRepository) Payment(id int) (*models.Payment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	payment, exists := r.state.payments.rows[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &payment, nil
+}
+
+func (r *MemoryRepository) PaymentsForReservation(reservationID int) ([]models.Payment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var payments []models.Payment
+	for _, payment := range r.state.payments.rows {
+		if payment.ReservationID == reservationID {
+			payments = append(payments, payment)
+		}
+	}
+	sort.Slice(payments, func(i, j int) bool { return payments[i].ID < payments[j].ID })
+	return payments, nil
+}
+
+func (r *MemoryRepository) SaveInvoice(invoice *models.Invoice) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if invoice.ID == 0 {
+		r.state.invoiceID++
+		invoice.ID = r.state.invoiceID
+	}
+	r.state.invoices.set(invoice.ID, *invoice)
+	return nil
+}
+
+func (r *MemoryRepository) Invoice(id int) (*models.Invoice, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	invoice, exists := r.state.invoices.rows[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &invoice, nil
+}
+
+func (r *MemoryRepository) Invoices() ([]models.Invoice, error) {
+	return r.invoices(func(models.Invoice) bool { return true })
+}
+
+func (r *MemoryRepository) InvoicesForReservation(reservationID int) ([]models.Invoice, error) {
+	return r.invoices(func(inv models.Invoice) bool { return inv.ReservationID == reservationID })
+}
+
+// invoices returns the invoices matching keep in the order they were
+// issued.
+func (r *MemoryRepository) invoices(keep func(models.Invoice) bool) ([]models.Invoice, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var invoices []models.Invoice
+	for _, invoice := range r.state.invoices.rows {
+		if keep(invoice) {
+			invoices = append(invoices, invoice)
+		}
+	}
+	sort.Slice(invoices, func(i, j int) bool { return invoices[i].ID < invoices[j].ID })
+	return invoices, nil
+}
+
+func (r *MemoryRepository) SaveWaitlistEntry(entry *models.WaitlistEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if entry.ID == 0 {
+		r.state.waitlistID++
+		entry.ID = r.state.waitlistID
+	}
+	r.state.waitlist.set(entry.ID, *entry)
+	return nil
+}
+
+func (r *MemoryRepository) WaitlistEntry(id int) (*models.WaitlistEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry, exists := r.state.waitlist.rows[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &entry, nil
+}
+
+func (r *MemoryRepository) WaitlistEntries() ([]models.WaitlistEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entries := make([]models.WaitlistEntry, 0, len(r.state.waitlist.rows))
+	for _, entry := range r.state.waitlist.rows {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+	return entries, nil
+}
+
+func (r *MemoryRepository) SaveHold(hold *models.Hold) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if hold.ID == 0 {
+		r.state.holdID++
+		hold.ID = r.state.holdID
+	}
+	r.state.holds.set(hold.ID, *hold)
+	return nil
+}
+
+func (r *MemoryRepository) Hold(id int) (*models.Hold, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	hold, exists := r.state.holds.rows[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &hold, nil
+}
+
+func (r *MemoryRepository) Holds() ([]models.Hold, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	holds := make([]models.Hold, 0, len(r.state.holds.rows))
+	for _, hold := range r.state.holds.rows {
+		holds = append(holds, hold)
+	}
+	sort.Slice(holds, func(i, j int) bool { return holds[i].ID < holds[j].ID })
+	return holds, nil
+}
+
+func (r *MemoryRepository) SaveExtra(extra *models.Extra) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.state.extras.set(extra.Code, *extra)
+	return nil
+}
+
+func (r *MemoryRepository) Extra(code string) (*models.Extra, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	extra, exists := r.state.extras.rows[code]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &extra, nil
+}
+
+func (r *MemoryRepository) Extras() ([]models.Extra, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	extras := make([]models.Extra, 0, len(r.state.extras.rows))
+	for _, extra := range r.state.extras.rows {
+		extras = append(extras, extra)
+	}
+	sort.Slice(extras, func(i, j int) bool { return extras[i].Code < extras[j].Code })
+	return extras, nil
+}
+
+func (r *MemoryRepository) SaveLoyaltyEntry(entry *models.LoyaltyEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if entry.ID == 0 {
+		r.state.loyaltyID++
+		entry.ID = r.state.loyaltyID
+	}
+	r.state.loyalty.set(entry.ID, *entry)
+	return nil
+}
+
+func (r *MemoryRepository) LoyaltyEntriesForCustomer(customerID int) ([]models.LoyaltyEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var entries []models.LoyaltyEntry
+	for _, entry := range r.state.loyalty.rows {
+		if entry.CustomerID == customerID {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+	return entries, nil
+}
+
+func (r *MemoryRepository) SavePromoCode(promo *models.PromoCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.state.promos.set(promo.Code, *promo)
+	return nil
+}
+
+func (r *MemoryRepository) PromoCode(code string) (*models.PromoCode, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	promo, exists := r.state.promos.rows[code]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &promo, nil
+}
+
+// LockPromoCode is PromoCode: writes to a memory repository are already
+// serialized by its one process.
+func (r *MemoryRepository) LockPromoCode(code string) (*models.PromoCode, error) {
+	return r.PromoCode(code)
+}
+
+func (r *MemoryRepository) PromoCodes() ([]models.PromoCode, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	promos := make([]models.PromoCode, 0, len(r.state.promos.rows))
+	for _, promo := range r.state.promos.rows {
+		promos = append(promos, promo)
+	}
+	sort.Slice(promos, func(i, j int) bool { return promos[i].Code < promos[j].Code })
+	return promos, nil
+}
+
+func (r *MemoryRepository) SavePromoRedemption(redemption *models.PromoRedemption) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if redemption.ID == 0 {
+		r.state.redemptionID++
+		redemption.ID = r.state.redemptionID
+	}
+	r.state.redemptions.set(redemption.ID, *redemption)
+	return nil
+}
+
+func (r *MemoryRepository) PromoRedemptions(code string) ([]models.PromoRedemption, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var redemptions []models.PromoRedemption
+	for _, redemption := range r.state.redemptions.rows {
+		if redemption.Code == code {
+			redemptions = append(redemptions, redemption)
+		}
+	}
+	sort.Slice(redemptions, func(i, j int) bool { return redemptions[i].ID < redemptions[j].ID })
+	return redemptions, nil
+}
+
+func (r *MemoryRepository) SaveExchangeRate(rate *models.ExchangeRate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rate.ID == 0 {
+		r.state.rateID++
+		rate.ID = r.state.rateID
+	}
+	r.state.rates.set(rate.ID, *rate)
+	return nil
+}
+
+func (r *MemoryRepository) ExchangeRates() ([]models.ExchangeRate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rates := make([]models.ExchangeRate, 0, len(r.state.rates.rows))
+	for _, rate := range r.state.rates.rows {
+		rates = append(rates, rate)
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i].ID < rates[j].ID })
+	return rates, nil
+}
+
+// Transaction works on a copy of the current state and swaps it in when fn
+// succeeds. The copy shares the current tables and only copies those fn
+// writes to. Concurrent writers outside the transaction are not merged, so
+// callers are expected to serialize writes themselves.
+func (r *MemoryRepository) Transaction(fn func(tx Repository) error) error {
+	r.mu.Lock()
+	tx := &MemoryRepository{state: r.state.share()}
+	r.mu.Unlock()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.state = tx.state
+	r.mu.Unlock()
+	return nil
+}
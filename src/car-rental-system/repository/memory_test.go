@@ -0,0 +1,72 @@
+package repository
+
+import (
+	models "car-rental-system/rental_system_models"
+	"testing"
+)
+
+func TestMemoryTransactionIsolation(t *testing.T) {
+	r := NewMemoryRepository()
+	if err := r.SaveCar(&models.Car{ID: 1, Make: "Toyota", Odometer: 100}); err != nil {
+		t.Fatal(err)
+	}
+	odometer := func(repo Repository) int {
+		t.Helper()
+		car, err := repo.Car(1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return car.Odometer
+	}
+
+	err := r.Transaction(func(tx Repository) error {
+		if err := tx.SaveCar(&models.Car{ID: 1, Make: "Toyota", Odometer: 200}); err != nil {
+			return err
+		}
+		if err := tx.SaveCustomer(&models.Customer{Name: "Ann Lee"}); err != nil {
+			return err
+		}
+		if got := odometer(r); got != 100 {
+			t.Errorf("outside the transaction: odometer = %d, want 100", got)
+		}
+		// A write outside the transaction is not seen inside it.
+		if err := r.SaveCar(&models.Car{ID: 2, Make: "Honda"}); err != nil {
+			return err
+		}
+		if _, err := tx.Car(2); err != ErrNotFound {
+			t.Errorf("car saved outside the transaction: got %v, want %v", err, ErrNotFound)
+		}
+		return errRollback
+	})
+	if err != errRollback {
+		t.Fatalf("Transaction: got %v, want %v", err, errRollback)
+	}
+	if got := odometer(r); got != 100 {
+		t.Errorf("after rollback: odometer = %d, want 100", got)
+	}
+	if _, err := r.Customer(1); err != ErrNotFound {
+		t.Errorf("customer after rollback: got %v, want %v", err, ErrNotFound)
+	}
+
+	if err := r.Transaction(func(tx Repository) error {
+		return tx.SaveCar(&models.Car{ID: 1, Make: "Toyota", Odometer: 300})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got := odometer(r); got != 300 {
+		t.Errorf("after commit: odometer = %d, want 300", got)
+	}
+	// A state once swapped out is left as it was, for snapshots to read.
+	before := r.state
+	if err := r.Transaction(func(tx Repository) error {
+		return tx.SaveCustomer(&models.Customer{Name: "Bo Chen"})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.SaveCar(&models.Car{ID: 3, Make: "Mazda"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(before.customers.rows) != 0 || len(before.cars.rows) != 2 {
+		t.Errorf("old state has %d customers and %d cars, want 0 and 2", len(before.customers.rows), len(before.cars.rows))
+	}
+}
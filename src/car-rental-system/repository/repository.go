@@ -0,0 +1,105 @@
+package repository
+
+import (
+	models "car-rental-system/rental_system_models"
+	"errors"
+)
+
+var ErrNotFound = errors.New("record not found")
+
+// Repository stores the cars, customers and reservations of the rental
+// system. Implementations hand out copies, so changes only take effect once
+// they are saved back.
+type Repository interface {
+	SaveCar(car *models.Car) error
+	Car(id int) (*models.Car, error)
+	Cars() ([]models.Car, error)
+
+	// SaveCustomer assigns an ID to new customers.
+	SaveCustomer(customer *models.Customer) error
+	Customer(id int) (*models.Customer, error)
+	CustomerByLicense(license string) (*models.Customer, error)
+	ReservationsForCustomer(customerID int) ([]models.Reservation, error)
+
+	SaveVehicleClass(class *models.VehicleClass) error
+	VehicleClass(code string) (*models.VehicleClass, error)
+	// VehicleClasses returns the classes ordered by rank.
+	VehicleClasses() ([]models.VehicleClass, error)
+
+	// SaveBranch assigns an ID to new branches.
+	SaveBranch(branch *models.Branch) error
+	Branch(id int) (*models.Branch, error)
+	Branches() ([]models.Branch, error)
+
+	// SaveMaintenanceWindow assigns an ID to new windows.
+	SaveMaintenanceWindow(window *models.MaintenanceWindow) error
+	MaintenanceWindow(id int) (*models.MaintenanceWindow, error)
+	MaintenanceWindows() ([]models.MaintenanceWindow, error)
+	MaintenanceWindowsForCar(carID int) ([]models.MaintenanceWindow, error)
+	DeleteMaintenanceWindow(id int) error
+
+	SaveBlockedLicense(entry *models.BlockedLicense) error
+	BlockedLicense(license string) (*models.BlockedLicense, error)
+	BlockedLicenses() ([]models.BlockedLicense, error)
+	DeleteBlockedLicense(license string) error
+
+	// SaveReservation assigns an ID to new reservations.
+	SaveReservation(res *models.Reservation) error
+	Reservation(id int) (*models.Reservation, error)
+	Reservations() ([]models.Reservation, error)
+	DeleteReservation(id int) error
+
+	// SavePayment assigns an ID to new payments.
+	SavePayment(payment *models.Payment) error
+	Payment(id int) (*models.Payment, error)
+	PaymentsForReservation(reservationID int) ([]models.Payment, error)
+
+	// SaveInvoice assigns an ID to new invoices and credit notes.
+	SaveInvoice(invoice *models.Invoice) error
+	Invoice(id int) (*models.Invoice, error)
+	// Invoices returns every invoice and credit note in the order issued.
+	Invoices() ([]models.Invoice, error)
+	InvoicesForReservation(reservationID int) ([]models.Invoice, error)
+
+	// SaveWaitlistEntry assigns an ID to new entries.
+	SaveWaitlistEntry(entry *models.WaitlistEntry) error
+	WaitlistEntry(id int) (*models.WaitlistEntry, error)
+	// WaitlistEntries returns every entry in the order they joined.
+	WaitlistEntries() ([]models.WaitlistEntry, error)
+
+	// SaveHold assigns an ID to new holds.
+	SaveHold(hold *models.Hold) error
+	Hold(id int) (*models.Hold, error)
+	Holds() ([]models.Hold, error)
+
+	SaveExtra(extra *models.Extra) error
+	Extra(code string) (*models.Extra, error)
+	// Extras returns the catalogue ordered by code.
+	Extras() ([]models.Extra, error)
+
+	// SaveLoyaltyEntry assigns an ID to new entries.
+	SaveLoyaltyEntry(entry *models.LoyaltyEntry) error
+	// LoyaltyEntriesForCustomer returns the customer's ledger oldest first.
+	LoyaltyEntriesForCustomer(customerID int) ([]models.LoyaltyEntry, error)
+
+	SavePromoCode(promo *models.PromoCode) error
+	PromoCode(code string) (*models.PromoCode, error)
+	// LockPromoCode is PromoCode for a transaction about to use the code:
+	// other transactions that lock it wait until this one ends.
+	LockPromoCode(code string) (*models.PromoCode, error)
+	// PromoCodes returns every code ordered by code.
+	PromoCodes() ([]models.PromoCode, error)
+	// SavePromoRedemption assigns an ID to new redemptions.
+	SavePromoRedemption(redemption *models.PromoRedemption) error
+	// PromoRedemptions returns the uses of a code oldest first.
+	PromoRedemptions(code string) ([]models.PromoRedemption, error)
+
+	// SaveExchangeRate assigns an ID to new rates.
+	SaveExchangeRate(rate *models.ExchangeRate) error
+	// ExchangeRates returns the whole table in the order rates were added.
+	ExchangeRates() ([]models.ExchangeRate, error)
+
+	// Transaction runs fn against a repository whose changes are only kept
+	// if fn returns nil.
+	Transaction(fn func(tx Repository) error) error
+}
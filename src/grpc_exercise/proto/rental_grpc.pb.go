@@ -0,0 +1,523 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: proto/rental.proto
+
+package greeter
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RentalService_AddCar_FullMethodName              = "/rental.RentalService/AddCar"
+	RentalService_SearchCars_FullMethodName          = "/rental.RentalService/SearchCars"
+	RentalService_ScheduleMaintenance_FullMethodName = "/rental.RentalService/ScheduleMaintenance"
+	RentalService_RegisterCustomer_FullMethodName    = "/rental.RentalService/RegisterCustomer"
+	RentalService_GetCustomerHistory_FullMethodName  = "/rental.RentalService/GetCustomerHistory"
+	RentalService_CreateReservation_FullMethodName   = "/rental.RentalService/CreateReservation"
+	RentalService_AssignCar_FullMethodName           = "/rental.RentalService/AssignCar"
+	RentalService_ModifyReservation_FullMethodName   = "/rental.RentalService/ModifyReservation"
+	RentalService_CancelReservation_FullMethodName   = "/rental.RentalService/CancelReservation"
+	RentalService_ProcessPayment_FullMethodName      = "/rental.RentalService/ProcessPayment"
+	RentalService_WatchAvailability_FullMethodName   = "/rental.RentalService/WatchAvailability"
+)
+
+// RentalServiceClient is the client API for RentalService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// RentalService exposes the car rental system to internal services.
+// Dates are "2006-01-02" days or RFC 3339 timestamps.
+type RentalServiceClient interface {
+	AddCar(ctx context.Context, in *AddCarRequest, opts ...grpc.CallOption) (*Car, error)
+	SearchCars(ctx context.Context, in *SearchCarsRequest, opts ...grpc.CallOption) (*SearchCarsReply, error)
+	// ScheduleMaintenance blocks a car and lists the reservations that clash
+	// with the window so they can be rebooked.
+	ScheduleMaintenance(ctx context.Context, in *ScheduleMaintenanceRequest, opts ...grpc.CallOption) (*ScheduleMaintenanceReply, error)
+	RegisterCustomer(ctx context.Context, in *RegisterCustomerRequest, opts ...grpc.CallOption) (*Customer, error)
+	GetCustomerHistory(ctx context.Context, in *GetCustomerHistoryRequest, opts ...grpc.CallOption) (*CustomerHistoryReply, error)
+	CreateReservation(ctx context.Context, in *CreateReservationRequest, opts ...grpc.CallOption) (*Reservation, error)
+	// AssignCar picks the car for a class-only reservation at pickup,
+	// upgrading for free when the booked class is sold out.
+	AssignCar(ctx context.Context, in *AssignCarRequest, opts ...grpc.CallOption) (*Reservation, error)
+	ModifyReservation(ctx context.Context, in *ModifyReservationRequest, opts ...grpc.CallOption) (*Reservation, error)
+	CancelReservation(ctx context.Context, in *CancelReservationRequest, opts ...grpc.CallOption) (*CancelReservationReply, error)
+	ProcessPayment(ctx context.Context, in *ProcessPaymentRequest, opts ...grpc.CallOption) (*Reservation, error)
+	// WatchAvailability sends the car's availability for the window right
+	// away and again every time it changes.
+	WatchAvailability(ctx context.Context, in *WatchAvailabilityRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AvailabilityUpdate], error)
+}
+
+type rentalServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRentalServiceClient(cc grpc.ClientConnInterface) RentalServiceClient {
+	return &rentalServiceClient{cc}
+}
+
+func (c *rentalServiceClient) AddCar(ctx context.Context, in *AddCarRequest, opts ...grpc.CallOption) (*Car, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Car)
+	err := c.cc.Invoke(ctx, RentalService_AddCar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalServiceClient) SearchCars(ctx context.Context, in *SearchCarsRequest, opts ...grpc.CallOption) (*SearchCarsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchCarsReply)
+	err := c.cc.Invoke(ctx, RentalService_SearchCars_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalServiceClient) ScheduleMaintenance(ctx context.Context, in *ScheduleMaintenanceRequest, opts ...grpc.CallOption) (*ScheduleMaintenanceReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduleMaintenanceReply)
+	err := c.cc.Invoke(ctx, RentalService_ScheduleMaintenance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalServiceClient) RegisterCustomer(ctx context.Context, in *RegisterCustomerRequest, opts ...grpc.CallOption) (*Customer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Customer)
+	err := c.cc.Invoke(ctx, RentalService_RegisterCustomer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalServiceClient) GetCustomerHistory(ctx context.Context, in *GetCustomerHistoryRequest, opts ...grpc.CallOption) (*CustomerHistoryReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CustomerHistoryReply)
+	err := c.cc.Invoke(ctx, RentalService_GetCustomerHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalServiceClient) CreateReservation(ctx context.Context, in *CreateReservationRequest, opts ...grpc.CallOption) (*Reservation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reservation)
+	err := c.cc.Invoke(ctx, RentalService_CreateReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalServiceClient) AssignCar(ctx context.Context, in *AssignCarRequest, opts ...grpc.CallOption) (*Reservation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reservation)
+	err := c.cc.Invoke(ctx, RentalService_AssignCar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalServiceClient) ModifyReservation(ctx context.Context, in *ModifyReservationRequest, opts ...grpc.CallOption) (*Reservation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reservation)
+	err := c.cc.Invoke(ctx, RentalService_ModifyReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalServiceClient) CancelReservation(ctx context.Context, in *CancelReservationRequest, opts ...grpc.CallOption) (*CancelReservationReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelReservationReply)
+	err := c.cc.Invoke(ctx, RentalService_CancelReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalServiceClient) ProcessPayment(ctx context.Context, in *ProcessPaymentRequest, opts ...grpc.CallOption) (*Reservation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reservation)
+	err := c.cc.Invoke(ctx, RentalService_ProcessPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalServiceClient) WatchAvailability(ctx context.Context, in *WatchAvailabilityRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AvailabilityUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RentalService_ServiceDesc.Streams[0], RentalService_WatchAvailability_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchAvailabilityRequest, AvailabilityUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RentalService_WatchAvailabilityClient = grpc.ServerStreamingClient[AvailabilityUpdate]
+
+// RentalServiceServer is the server API for RentalService service.
+// All implementations must embed UnimplementedRentalServiceServer
+// for forward compatibility.
+//
+// RentalService exposes the car rental system to internal services.
+// Dates are "2006-01-02" days or RFC 3339 timestamps.
+type RentalServiceServer interface {
+	AddCar(context.Context, *AddCarRequest) (*Car, error)
+	SearchCars(context.Context, *SearchCarsRequest) (*SearchCarsReply, error)
+	// ScheduleMaintenance blocks a car and lists the reservations that clash
+	// with the window so they can be rebooked.
+	ScheduleMaintenance(context.Context, *ScheduleMaintenanceRequest) (*ScheduleMaintenanceReply, error)
+	RegisterCustomer(context.Context, *RegisterCustomerRequest) (*Customer, error)
+	GetCustomerHistory(context.Context, *GetCustomerHistoryRequest) (*CustomerHistoryReply, error)
+	CreateReservation(context.Context, *CreateReservationRequest) (*Reservation, error)
+	// AssignCar picks the car for a class-only reservation at pickup,
+	// upgrading for free when the booked class is sold out.
+	AssignCar(context.Context, *AssignCarRequest) (*Reservation, error)
+	ModifyReservation(context.Context, *ModifyReservationRequest) (*Reservation, error)
+	CancelReservation(context.Context, *CancelReservationRequest) (*CancelReservationReply, error)
+	ProcessPayment(context.Context, *ProcessPaymentRequest) (*Reservation, error)
+	// WatchAvailability sends the car's availability for the window right
+	// away and again every time it changes.
+	WatchAvailability(*WatchAvailabilityRequest, grpc.ServerStreamingServer[AvailabilityUpdate]) error
+	mustEmbedUnimplementedRentalServiceServer()
+}
+
+// UnimplementedRentalServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRentalServiceServer struct{}
+
+func (UnimplementedRentalServiceServer) AddCar(context.Context, *AddCarRequest) (*Car, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCar not implemented")
+}
+func (UnimplementedRentalServiceServer) SearchCars(context.Context, *SearchCarsRequest) (*SearchCarsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchCars not implemented")
+}
+func (UnimplementedRentalServiceServer) ScheduleMaintenance(context.Context, *ScheduleMaintenanceRequest) (*ScheduleMaintenanceReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleMaintenance not implemented")
+}
+func (UnimplementedRentalServiceServer) RegisterCustomer(context.Context, *RegisterCustomerRequest) (*Customer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterCustomer not implemented")
+}
+func (UnimplementedRentalServiceServer) GetCustomerHistory(context.Context, *GetCustomerHistoryRequest) (*CustomerHistoryReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCustomerHistory not implemented")
+}
+func (UnimplementedRentalServiceServer) CreateReservation(context.Context, *CreateReservationRequest) (*Reservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateReservation not implemented")
+}
+func (UnimplementedRentalServiceServer) AssignCar(context.Context, *AssignCarRequest) (*Reservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignCar not implemented")
+}
+func (UnimplementedRentalServiceServer) ModifyReservation(context.Context, *ModifyReservationRequest) (*Reservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ModifyReservation not implemented")
+}
+func (UnimplementedRentalServiceServer) CancelReservation(context.Context, *CancelReservationRequest) (*CancelReservationReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelReservation not implemented")
+}
+func (UnimplementedRentalServiceServer) ProcessPayment(context.Context, *ProcessPaymentRequest) (*Reservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProcessPayment not implemented")
+}
+func (UnimplementedRentalServiceServer) WatchAvailability(*WatchAvailabilityRequest, grpc.ServerStreamingServer[AvailabilityUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchAvailability not implemented")
+}
+func (UnimplementedRentalServiceServer) mustEmbedUnimplementedRentalServiceServer() {}
+func (UnimplementedRentalServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeRentalServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RentalServiceServer will
+// result in compilation errors.
+type UnsafeRentalServiceServer interface {
+	mustEmbedUnimplementedRentalServiceServer()
+}
+
+func RegisterRentalServiceServer(s grpc.ServiceRegistrar, srv RentalServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRentalServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RentalService_ServiceDesc, srv)
+}
+
+func _RentalService_AddCar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalServiceServer).AddCar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalService_AddCar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalServiceServer).AddCar(ctx, req.(*AddCarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalService_SearchCars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchCarsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalServiceServer).SearchCars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalService_SearchCars_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalServiceServer).SearchCars(ctx, req.(*SearchCarsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalService_ScheduleMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleMaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalServiceServer).ScheduleMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalService_ScheduleMaintenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalServiceServer).ScheduleMaintenance(ctx, req.(*ScheduleMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalService_RegisterCustomer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterCustomerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalServiceServer).RegisterCustomer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalService_RegisterCustomer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalServiceServer).RegisterCustomer(ctx, req.(*RegisterCustomerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalService_GetCustomerHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCustomerHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalServiceServer).GetCustomerHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalService_GetCustomerHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalServiceServer).GetCustomerHistory(ctx, req.(*GetCustomerHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalService_CreateReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalServiceServer).CreateReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalService_CreateReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalServiceServer).CreateReservation(ctx, req.(*CreateReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalService_AssignCar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignCarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalServiceServer).AssignCar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalService_AssignCar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalServiceServer).AssignCar(ctx, req.(*AssignCarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalService_ModifyReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModifyReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalServiceServer).ModifyReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalService_ModifyReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalServiceServer).ModifyReservation(ctx, req.(*ModifyReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalService_CancelReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalServiceServer).CancelReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalService_CancelReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalServiceServer).CancelReservation(ctx, req.(*CancelReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalService_ProcessPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalServiceServer).ProcessPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalService_ProcessPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalServiceServer).ProcessPayment(ctx, req.(*ProcessPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalService_WatchAvailability_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchAvailabilityRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RentalServiceServer).WatchAvailability(m, &grpc.GenericServerStream[WatchAvailabilityRequest, AvailabilityUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RentalService_WatchAvailabilityServer = grpc.ServerStreamingServer[AvailabilityUpdate]
+
+// RentalService_ServiceDesc is the grpc.ServiceDesc for RentalService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RentalService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rental.RentalService",
+	HandlerType: (*RentalServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddCar",
+			Handler:    _RentalService_AddCar_Handler,
+		},
+		{
+			MethodName: "SearchCars",
+			Handler:    _RentalService_SearchCars_Handler,
+		},
+		{
+			MethodName: "ScheduleMaintenance",
+			Handler:    _RentalService_ScheduleMaintenance_Handler,
+		},
+		{
+			MethodName: "RegisterCustomer",
+			Handler:    _RentalService_RegisterCustomer_Handler,
+		},
+		{
+			MethodName: "GetCustomerHistory",
+			Handler:    _RentalService_GetCustomerHistory_Handler,
+		},
+		{
+			MethodName: "CreateReservation",
+			Handler:    _RentalService_CreateReservation_Handler,
+		},
+		{
+			MethodName: "AssignCar",
+			Handler:    _RentalService_AssignCar_Handler,
+		},
+		{
+			MethodName: "ModifyReservation",
+			Handler:    _RentalService_ModifyReservation_Handler,
+		},
+		{
+			MethodName: "CancelReservation",
+			Handler:    _RentalService_CancelReservation_Handler,
+		},
+		{
+			MethodName: "ProcessPayment",
+			Handler:    _RentalService_ProcessPayment_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchAvailability",
+			Handler:       _RentalService_WatchAvailability_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/rental.proto",
+}
@@ -0,0 +1,545 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"car-rental-system/eligibility"
+	"car-rental-system/events"
+	services "car-rental-system/handlers"
+	"car-rental-system/money"
+	models "car-rental-system/rental_system_models"
+	"car-rental-system/repository"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "grpc_exercise/proto"
+)
+
+// statusCodes maps the rental system's errors to gRPC status codes.
+var statusCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{services.ErrCarNotFound, codes.NotFound},
+	{services.ErrVehicleClassNotFound, codes.NotFound},
+	{services.ErrVehicleClassExists, codes.AlreadyExists},
+	{services.ErrInvalidVehicleClass, codes.InvalidArgument},
+	{services.ErrClassSoldOut, codes.FailedPrecondition},
+	{services.ErrCarAlreadyAssigned, codes.FailedPrecondition},
+	{services.ErrCarOrClassRequired, codes.InvalidArgument},
+	{services.ErrInvalidSearch, codes.InvalidArgument},
+	{services.ErrInvalidCursor, codes.InvalidArgument},
+	{services.ErrBranchNotFound, codes.NotFound},
+	{services.ErrInvalidBranch, codes.InvalidArgument},
+	{services.ErrBranchClosed, codes.FailedPrecondition},
+	{services.ErrCarNotAtBranch, codes.FailedPrecondition},
+	{services.ErrCustomerNotFound, codes.NotFound},
+	{services.ErrDuplicateLicense, codes.AlreadyExists},
+	{services.ErrInvalidCustomer, codes.InvalidArgument},
+	{services.ErrLicenseNotBlocked, codes.NotFound},
+	{eligibility.ErrInvalidLicense, codes.FailedPrecondition},
+	{eligibility.ErrLicenseExpired, codes.FailedPrecondition},
+	{eligibility.ErrDriverAge, codes.FailedPrecondition},
+	{eligibility.ErrMissingDriverDetails, codes.FailedPrecondition},
+	{eligibility.ErrLicenseBlocked, codes.PermissionDenied},
+	{services.ErrReservationNotFound, codes.NotFound},
+	{services.ErrCarAlreadyExists, codes.AlreadyExists},
+	{services.ErrCarRetired, codes.FailedPrecondition},
+	{services.ErrInvalidCarStatus, codes.InvalidArgument},
+	{services.ErrMaintenanceNotFound, codes.NotFound},
+	{services.ErrCarNotAvailable, codes.FailedPrecondition},
+	{services.ErrReservationCancelled, codes.FailedPrecondition},
+	{services.ErrAlreadyPickedUp, codes.FailedPrecondition},
+	{services.ErrNotPickedUp, codes.FailedPrecondition},
+	{services.ErrReservationCompleted, codes.FailedPrecondition},
+	{services.ErrPickupTooEarly, codes.FailedPrecondition},
+	{services.ErrInvalidHandover, codes.InvalidArgument},
+	{services.ErrAlreadyPaid, codes.FailedPrecondition},
+	{services.ErrPaymentNotFound, codes.NotFound},
+	{services.ErrInvoiceNotFound, codes.NotFound},
+	{services.ErrWaitlistNotFound, codes.NotFound},
+	{services.ErrAlreadyWaitlisted, codes.AlreadyExists},
+	{services.ErrWaitlistEntryClosed, codes.FailedPrecondition},
+	{services.ErrNoWaitlistOffer, codes.FailedPrecondition},
+	{services.ErrWaitlistOfferExpired, codes.FailedPrecondition},
+	{services.ErrHoldNotFound, codes.NotFound},
+	{services.ErrHoldNeedsCar, codes.InvalidArgument},
+	{services.ErrHoldNotActive, codes.FailedPrecondition},
+	{services.ErrHoldExpired, codes.FailedPrecondition},
+	{services.ErrExtraNotFound, codes.NotFound},
+	{services.ErrExtraExists, codes.AlreadyExists},
+	{services.ErrInvalidExtra, codes.InvalidArgument},
+	{services.ErrExtraNotStocked, codes.FailedPrecondition},
+	{services.ErrExtraQuantity, codes.InvalidArgument},
+	{services.ErrExtraSoldOut, codes.ResourceExhausted},
+	{services.ErrInvalidPoints, codes.InvalidArgument},
+	{services.ErrInvalidLoyaltyPolicy, codes.InvalidArgument},
+	{services.ErrInsufficientPoints, codes.FailedPrecondition},
+	{services.ErrInvalidUsagePolicy, codes.InvalidArgument},
+	{services.ErrPromoNotFound, codes.NotFound},
+	{services.ErrPromoExists, codes.AlreadyExists},
+	{services.ErrInvalidPromo, codes.InvalidArgument},
+	{services.ErrPromoNotValid, codes.FailedPrecondition},
+	{services.ErrPromoNotApplicable, codes.FailedPrecondition},
+	{services.ErrPromoUsedUp, codes.ResourceExhausted},
+	{services.ErrPromoNotStackable, codes.InvalidArgument},
+	{services.ErrInvalidExchangeRate, codes.InvalidArgument},
+	{services.ErrPaymentDeclined, codes.FailedPrecondition},
+	{services.ErrInvalidPaymentState, codes.FailedPrecondition},
+	{services.ErrInvalidAmount, codes.InvalidArgument},
+	{services.ErrCurrencyMismatch, codes.InvalidArgument},
+	{money.ErrInvalidCurrency, codes.InvalidArgument},
+	{money.ErrNoRate, codes.FailedPrecondition},
+	{money.ErrCurrencyMismatch, codes.InvalidArgument},
+	{services.ErrInvalidDate, codes.InvalidArgument},
+	{services.ErrEndBeforeStart, codes.InvalidArgument},
+	{services.ErrDateInPast, codes.InvalidArgument},
+}
+
+func toStatus(err error) error {
+	for _, sc := range statusCodes {
+		if errors.Is(err, sc.err) {
+			return status.Error(sc.code, err.Error())
+		}
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// recoverUnary turns a panic in a handler into an Internal error for that
+// call instead of taking the whole server down. It is a last resort for
+// bugs: errors callers can cause, such as amounts in the wrong currency,
+// come back from the rental system as errors and never get here.
+func recoverUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("panic in %s: %v\n%s", info.FullMethod, r, debug.Stack())
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// recoverStream does for streams what recoverUnary does for unary calls.
+func recoverStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("panic in %s: %v\n%s", info.FullMethod, r, debug.Stack())
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+	return handler(srv, ss)
+}
+
+// changeNotifier wakes up every WatchAvailability stream after a booking
+// changes. It hears about bookings from the rental system's event bus.
+type changeNotifier struct {
+	mu       sync.Mutex
+	watchers map[chan struct{}]struct{}
+}
+
+func (n *changeNotifier) subscribe() chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	ch := make(chan struct{}, 1)
+	n.watchers[ch] = struct{}{}
+	return ch
+}
+
+func (n *changeNotifier) unsubscribe(ch chan struct{}) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.watchers, ch)
+}
+
+func (n *changeNotifier) Handle(events.Event) {
+	n.notify()
+}
+
+func (n *changeNotifier) notify() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for ch := range n.watchers {
+		select {
+		case ch <- struct{}{}:
+		default: // a wake-up is already pending
+		}
+	}
+}
+
+type server struct {
+	pb.UnimplementedRentalServiceServer
+	rentals *services.RentalSystem
+	changes *changeNotifier
+}
+
+func toMoney(m money.Money) *pb.Money {
+	return &pb.Money{CurrencyCode: string(m.Currency), MinorUnits: m.Amount}
+}
+
+// fromMoney reads an amount from a request. An unset amount is zero.
+func fromMoney(m *pb.Money) money.Money {
+	return money.New(m.GetMinorUnits(), money.Currency(m.GetCurrencyCode()))
+}
+
+func toCar(car models.Car) *pb.Car {
+	return &pb.Car{
+		Id:                int32(car.ID),
+		Make:              car.Make,
+		Model:             car.Model,
+		Year:              int32(car.Year),
+		LicensePlate:      car.LicensePlate,
+		RentalPricePerDay: toMoney(car.RentalPricePerDay),
+		Class:             car.Class,
+		Status:            string(car.Status),
+		BranchId:          int32(car.BranchID),
+	}
+}
+
+const dateLayout = "2006-01-02"
+
+// formatDate renders a calendar date, leaving unknown dates empty.
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(dateLayout)
+}
+
+// parseDate reads an optional calendar date field.
+func parseDate(field, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	parsed, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "%s must be YYYY-MM-DD", field)
+	}
+	return parsed, nil
+}
+
+func toCustomer(customer *models.Customer) *pb.Customer {
+	return &pb.Customer{
+		Id:             int32(customer.ID),
+		Name:           customer.Name,
+		Email:          customer.Email,
+		Phone:          customer.Phone,
+		DriversLicense: customer.DriversLicense,
+		DateOfBirth:    formatDate(customer.DateOfBirth),
+		LicenseRegion:  customer.LicenseRegion,
+		LicenseExpiry:  formatDate(customer.LicenseExpiry),
+		Address: &pb.Address{
+			Street:     customer.Address.Street,
+			City:       customer.Address.City,
+			PostalCode: customer.Address.PostalCode,
+			Country:    customer.Address.Country,
+		},
+	}
+}
+
+func fromCustomer(c *pb.Customer) (models.Customer, error) {
+	customer := models.Customer{
+		Name:           c.GetName(),
+		Email:          c.GetEmail(),
+		Phone:          c.GetPhone(),
+		DriversLicense: c.GetDriversLicense(),
+		LicenseRegion:  c.GetLicenseRegion(),
+		Address: models.Address{
+			Street:     c.GetAddress().GetStreet(),
+			City:       c.GetAddress().GetCity(),
+			PostalCode: c.GetAddress().GetPostalCode(),
+			Country:    c.GetAddress().GetCountry(),
+		},
+	}
+	var err error
+	if customer.DateOfBirth, err = parseDate("date_of_birth", c.GetDateOfBirth()); err != nil {
+		return customer, err
+	}
+	if customer.LicenseExpiry, err = parseDate("license_expiry", c.GetLicenseExpiry()); err != nil {
+		return customer, err
+	}
+	return customer, nil
+}
+
+func toReservation(res *models.Reservation) *pb.Reservation {
+	reply := &pb.Reservation{
+		Id:              int32(res.ID),
+		CustomerId:      int32(res.CustomerID),
+		PickupBranchId:  int32(res.PickupBranchID),
+		DropoffBranchId: int32(res.DropoffBranchID),
+		VehicleClass:    res.VehicleClass,
+		ClassOnly:       res.ClassOnly,
+		Upgraded:        res.Upgraded,
+		CarId:           int32(res.CarID),
+		StartDate:       res.StartDate.Format(time.RFC3339),
+		EndDate:         res.EndDate.Format(time.RFC3339),
+		RentalDays:      int32(res.RentalDays),
+		TotalPrice:      toMoney(res.TotalPrice),
+		Paid:            res.Paid,
+		AmountPaid:      toMoney(res.AmountPaid),
+		AmountDue:       toMoney(res.AmountDue),
+		RefundDue:       toMoney(res.RefundDue),
+		Status:          string(res.Status),
+		CancellationFee: toMoney(res.CancellationFee),
+	}
+	for _, line := range res.PriceBreakdown {
+		reply.PriceBreakdown = append(reply.PriceBreakdown, &pb.PriceLine{
+			Code:        line.Code,
+			Description: line.Description,
+			Quantity:    int32(line.Quantity),
+			UnitPrice:   toMoney(line.UnitPrice),
+			Amount:      toMoney(line.Amount),
+		})
+	}
+	return reply
+}
+
+func (s *server) AddCar(ctx context.Context, req *pb.AddCarRequest) (*pb.Car, error) {
+	c := req.GetCar()
+	car := models.Car{
+		ID:                int(c.GetId()),
+		Make:              c.GetMake(),
+		Model:             c.GetModel(),
+		Year:              int(c.GetYear()),
+		LicensePlate:      c.GetLicensePlate(),
+		RentalPricePerDay: fromMoney(c.GetRentalPricePerDay()),
+		Class:             c.GetClass(),
+		Status:            models.CarStatus(c.GetStatus()),
+		BranchID:          int(c.GetBranchId()),
+	}
+	if err := s.rentals.AddCar(car); err != nil {
+		return nil, toStatus(err)
+	}
+	return toCar(car), nil
+}
+
+var searchSorts = map[pb.SearchCarsRequest_Sort]services.SearchSort{
+	pb.SearchCarsRequest_SORT_ID:         services.SortByID,
+	pb.SearchCarsRequest_SORT_PRICE:      services.SortByPrice,
+	pb.SearchCarsRequest_SORT_YEAR:       services.SortByYear,
+	pb.SearchCarsRequest_SORT_POPULARITY: services.SortByPopularity,
+}
+
+func (s *server) SearchCars(ctx context.Context, req *pb.SearchCarsRequest) (*pb.SearchCarsReply, error) {
+	sort, ok := searchSorts[req.GetSort()]
+	if !ok {
+		return nil, toStatus(services.ErrInvalidSearch)
+	}
+	result, err := s.rentals.SearchCars(services.SearchCriteria{
+		Make:       req.GetMake(),
+		Model:      req.GetModel(),
+		MinYear:    int(req.GetMinYear()),
+		MaxYear:    int(req.GetMaxYear()),
+		MinPrice:   fromMoney(req.GetMinPrice()),
+		MaxPrice:   fromMoney(req.GetMaxPrice()),
+		Class:      req.GetVehicleClass(),
+		MinSeats:   int(req.GetMinSeats()),
+		BranchID:   int(req.GetPickupBranchId()),
+		StartDate:  req.GetStartDate(),
+		EndDate:    req.GetEndDate(),
+		Sort:       sort,
+		Descending: req.GetDescending(),
+		Limit:      int(req.GetLimit()),
+		Offset:     int(req.GetOffset()),
+		Cursor:     req.GetCursor(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	reply := &pb.SearchCarsReply{Total: int32(result.Total), NextCursor: result.NextCursor}
+	for _, car := range result.Cars {
+		reply.Cars = append(reply.Cars, toCar(car))
+	}
+	return reply, nil
+}
+
+func (s *server) ScheduleMaintenance(ctx context.Context, req *pb.ScheduleMaintenanceRequest) (*pb.ScheduleMaintenanceReply, error) {
+	result, err := s.rentals.ScheduleMaintenance(int(req.GetCarId()), req.GetStartDate(), req.GetEndDate(), req.GetReason())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	s.changes.notify()
+
+	reply := &pb.ScheduleMaintenanceReply{WindowId: int32(result.Window.ID)}
+	for i := range result.AffectedReservations {
+		reply.AffectedReservations = append(reply.AffectedReservations, toReservation(&result.AffectedReservations[i]))
+	}
+	return reply, nil
+}
+
+func (s *server) RegisterCustomer(ctx context.Context, req *pb.RegisterCustomerRequest) (*pb.Customer, error) {
+	customer, err := fromCustomer(req.GetCustomer())
+	if err != nil {
+		return nil, err
+	}
+	registered, err := s.rentals.RegisterCustomer(customer)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toCustomer(registered), nil
+}
+
+func (s *server) GetCustomerHistory(ctx context.Context, req *pb.GetCustomerHistoryRequest) (*pb.CustomerHistoryReply, error) {
+	history, err := s.rentals.CustomerHistory(int(req.GetCustomerId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	reply := &pb.CustomerHistoryReply{}
+	for i := range history {
+		reply.Reservations = append(reply.Reservations, toReservation(&history[i]))
+	}
+	return reply, nil
+}
+
+func (s *server) CreateReservation(ctx context.Context, req *pb.CreateReservationRequest) (*pb.Reservation, error) {
+	res, err := s.rentals.CreateReservation(services.ReservationRequest{
+		CustomerID:      int(req.GetCustomerId()),
+		CarID:           int(req.GetCarId()),
+		VehicleClass:    req.GetVehicleClass(),
+		StartDate:       req.GetStartDate(),
+		EndDate:         req.GetEndDate(),
+		PickupBranchID:  int(req.GetPickupBranchId()),
+		DropoffBranchID: int(req.GetDropoffBranchId()),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toReservation(res), nil
+}
+
+func (s *server) AssignCar(ctx context.Context, req *pb.AssignCarRequest) (*pb.Reservation, error) {
+	res, err := s.rentals.AssignCar(int(req.GetReservationId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toReservation(res), nil
+}
+
+func (s *server) ModifyReservation(ctx context.Context, req *pb.ModifyReservationRequest) (*pb.Reservation, error) {
+	id := int(req.GetReservationId())
+	if err := s.rentals.ModifyReservation(id, req.GetStartDate(), req.GetEndDate()); err != nil {
+		return nil, toStatus(err)
+	}
+
+	res, err := s.rentals.GetReservation(id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toReservation(res), nil
+}
+
+func (s *server) CancelReservation(ctx context.Context, req *pb.CancelReservationRequest) (*pb.CancelReservationReply, error) {
+	result, err := s.rentals.CancelReservation(int(req.GetReservationId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.CancelReservationReply{Fee: toMoney(result.Fee), Refund: toMoney(result.Refund), AmountDue: toMoney(result.AmountDue)}, nil
+}
+
+func (s *server) ProcessPayment(ctx context.Context, req *pb.ProcessPaymentRequest) (*pb.Reservation, error) {
+	id := int(req.GetReservationId())
+	method := req.GetMethod()
+	if method == "" {
+		method = "card"
+	}
+	if _, err := s.rentals.ProcessPayment(id, fromMoney(req.GetAmount()), method); err != nil {
+		return nil, toStatus(err)
+	}
+
+	res, err := s.rentals.GetReservation(id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toReservation(res), nil
+}
+
+func (s *server) WatchAvailability(req *pb.WatchAvailabilityRequest, stream grpc.ServerStreamingServer[pb.AvailabilityUpdate]) error {
+	changed := s.changes.subscribe()
+	defer s.changes.unsubscribe(changed)
+
+	carID := int(req.GetCarId())
+	first := true
+	var last bool
+	for {
+		available, err := s.rentals.IsCarAvailable(carID, req.GetStartDate(), req.GetEndDate())
+		if err != nil {
+			return toStatus(err)
+		}
+		if first || available != last {
+			update := &pb.AvailabilityUpdate{
+				CarId:     req.GetCarId(),
+				StartDate: req.GetStartDate(),
+				EndDate:   req.GetEndDate(),
+				Available: available,
+			}
+			if err := stream.Send(update); err != nil {
+				return err
+			}
+			first, last = false, available
+		}
+
+		select {
+		case <-changed:
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func main() {
+	addr := flag.String("addr", ":50052", "address the RentalService listens on")
+	sqlitePath := flag.String("sqlite", "", "path of a SQLite database file; memory when empty")
+	journalDir := flag.String("journal", "", "directory of an event-sourced journal, used instead of -sqlite")
+	flag.Parse()
+
+	var repo repository.Repository = repository.NewMemoryRepository()
+	switch {
+	case *journalDir != "":
+		journal, err := repository.OpenJournal(*journalDir)
+		if err != nil {
+			log.Fatalf("failed to open journal: %v", err)
+		}
+		defer journal.Close()
+		repo = journal
+	case *sqlitePath != "":
+		db, err := repository.OpenSQLite(*sqlitePath)
+		if err != nil {
+			log.Fatalf("failed to open database: %v", err)
+		}
+		repo = db
+	}
+	bus := events.NewBus()
+	defer bus.Close()
+	changes := &changeNotifier{watchers: make(map[chan struct{}]struct{})}
+	bus.Subscribe(changes)
+
+	rentals, err := services.NewRentalSystemWithRepository(repo, services.WithEventBus(bus))
+	if err != nil {
+		log.Fatalf("failed to load rental system: %v", err)
+	}
+	stopReaper := rentals.StartReaper(15*time.Second, func(err error) {
+		log.Printf("reaper: %v", err)
+	})
+	defer stopReaper()
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(recoverUnary), grpc.ChainStreamInterceptor(recoverStream))
+	pb.RegisterRentalServiceServer(s, &server{
+		rentals: rentals,
+		changes: changes,
+	})
+	log.Printf("server listening at %v", lis.Addr())
+	if err := s.Serve(lis); err != nil {
+		log.Fatalf("failed to serve: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorNames returns the exported Err* variables declared in a Go file, or
+// selected from package pkg in it when pkg is not empty.
+func errorNames(t *testing.T, path, pkg string) map[string]bool {
+	t.Helper()
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := map[string]bool{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ValueSpec:
+			for _, name := range n.Names {
+				if pkg == "" && strings.HasPrefix(name.Name, "Err") {
+					names[name.Name] = true
+				}
+			}
+		case *ast.SelectorExpr:
+			if id, ok := n.X.(*ast.Ident); ok && pkg != "" && id.Name == pkg && strings.HasPrefix(n.Sel.Name, "Err") {
+				names[n.Sel.Name] = true
+			}
+		}
+		return true
+	})
+	return names
+}
+
+func TestStatusCodesCoverRentalErrors(t *testing.T) {
+	declared := errorNames(t, "../../car-rental-system/handlers/errors.go", "")
+	mapped := errorNames(t, "main.go", "services")
+	if len(declared) == 0 {
+		t.Fatal("no errors found in the rental system")
+	}
+	for name := range declared {
+		if !mapped[name] {
+			t.Errorf("services.%s has no status code and would be reported as Internal", name)
+		}
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	for _, sc := range statusCodes {
+		t.Run(sc.err.Error(), func(t *testing.T) {
+			err := toStatus(fmt.Errorf("booking: %w", sc.err))
+			if got := status.Code(err); got != sc.code {
+				t.Errorf("code = %v, want %v", got, sc.code)
+			}
+		})
+	}
+	if got := status.Code(toStatus(fmt.Errorf("disk full"))); got != codes.Internal {
+		t.Errorf("unknown error: code = %v, want %v", got, codes.Internal)
+	}
+}